@@ -3,6 +3,7 @@ package conversions
 import (
 	"encoding/json"
 	"encoding/xml"
+	"github.com/crholm/iop/highlight"
 	"github.com/pelletier/go-toml/v2"
 	"github.com/urfave/cli/v3"
 	"gopkg.in/yaml.v3"
@@ -24,9 +25,9 @@ var Commands = []*cli.Command{
 				Aliases: []string{"H"},
 			},
 		},
-		Action: csvTo(func(w io.Writer) encoder {
+		Action: highlight.Wrap("yaml", csvTo(func(w io.Writer) encoder {
 			return yaml.NewEncoder(w)
-		}),
+		})),
 	},
 	{
 		Name:  "csv-to-json",
@@ -42,9 +43,9 @@ var Commands = []*cli.Command{
 				Aliases: []string{"H"},
 			},
 		},
-		Action: csvTo(func(w io.Writer) encoder {
+		Action: highlight.Wrap("json", csvTo(func(w io.Writer) encoder {
 			return json.NewEncoder(w)
-		}),
+		})),
 	},
 	{
 		Name:  "csv-to-xml",
@@ -60,9 +61,9 @@ var Commands = []*cli.Command{
 				Aliases: []string{"H"},
 			},
 		},
-		Action: csvTo(func(w io.Writer) encoder {
+		Action: highlight.Wrap("xml", csvTo(func(w io.Writer) encoder {
 			return xml.NewEncoder(w)
-		}),
+		})),
 	},
 	{
 		Name:  "csv-to-toml",
@@ -78,9 +79,9 @@ var Commands = []*cli.Command{
 				Aliases: []string{"H"},
 			},
 		},
-		Action: csvTo(func(w io.Writer) encoder {
+		Action: highlight.Wrap("toml", csvTo(func(w io.Writer) encoder {
 			return toml.NewEncoder(w)
-		}),
+		})),
 	},
 
 	// JSON-
@@ -96,38 +97,80 @@ var Commands = []*cli.Command{
 		},
 		Action: toCsv(decoderJSON),
 	},
+	{
+		Name:  "markdown-to-csv",
+		Usage: "converts the first table of a markdown document to csv",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "delimiter",
+				Aliases: []string{"d"},
+				Value:   ",",
+			},
+		},
+		Action: markdownToCsv,
+	},
 	{
 		Name:   "json-to-xml",
 		Usage:  "converts json to xml (WARNING: works poorly, xml is broken)",
-		Action: stdFromTo(decoderJSON, encoderXML),
+		Action: highlight.Wrap("xml", stdFromTo(decoderJSON, encoderXML)),
 	},
 
 	{
 		Name:   "json-to-toml",
 		Usage:  "converts json to toml",
-		Action: stdFromTo(decoderJSON, encoderTOML),
+		Action: highlight.Wrap("toml", stdFromTo(decoderJSON, encoderTOML)),
 	},
 	{
 		Name:   "json-to-yaml",
 		Usage:  "converts json to yaml",
-		Action: stdFromTo(decoderJSON, encoderYAML),
+		Action: highlight.Wrap("yaml", stdFromTo(decoderJSON, encoderYAML)),
+	},
+	{
+		Name:   "json-to-url",
+		Usage:  "converts a json object to a url query string, arrays become repeated keys",
+		Action: jsonToUrl,
+	},
+	{
+		Name:   "json-to-go",
+		Usage:  "generates go struct definitions from a json sample",
+		Flags:  goStructFlags("json"),
+		Action: toGo(decoderJSON),
+	},
+
+	// URL -
+	{
+		Name:  "url-to-json",
+		Usage: "converts a url query string, or the query of a url, to a json object, repeated keys become arrays",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "arrays",
+				Usage: "always use arrays, also for keys that are not repeated",
+			},
+		},
+		Action: highlight.Wrap("json", urlToJson),
 	},
 
 	// TOML -
 	{
 		Name:   "toml-to-xml",
 		Usage:  "converts toml to xml (WARNING: works poorly, xml is broken)",
-		Action: stdFromTo(decoderTOML, encoderXML),
+		Action: highlight.Wrap("xml", stdFromTo(decoderTOML, encoderXML)),
 	},
 	{
 		Name:   "toml-to-json",
 		Usage:  "converts toml to json",
-		Action: stdFromTo(decoderTOML, encoderJSON),
+		Action: highlight.Wrap("json", stdFromTo(decoderTOML, encoderJSON)),
 	},
 	{
 		Name:   "toml-to-yaml",
 		Usage:  "converts toml to yaml",
-		Action: stdFromTo(decoderTOML, encoderYAML),
+		Action: highlight.Wrap("yaml", stdFromTo(decoderTOML, encoderYAML)),
+	},
+	{
+		Name:   "toml-to-go",
+		Usage:  "generates go struct definitions from a toml sample",
+		Flags:  goStructFlags("toml"),
+		Action: toGo(decoderTOML),
 	},
 
 	// YAML -
@@ -146,17 +189,23 @@ var Commands = []*cli.Command{
 	{
 		Name:   "yaml-to-xml",
 		Usage:  "converts yaml to xml (WARNING: works poorly, xml is broken)",
-		Action: stdFromTo(decoderYAML, encoderXML),
+		Action: highlight.Wrap("xml", stdFromTo(decoderYAML, encoderXML)),
 	},
 	{
 		Name:   "yaml-to-json",
 		Usage:  "converts yaml to json",
-		Action: stdFromTo(decoderYAML, encoderJSON),
+		Action: highlight.Wrap("json", stdFromTo(decoderYAML, encoderJSON)),
 	},
 	{
 		Name:   "yaml-to-toml",
 		Usage:  "converts toml to yaml",
-		Action: stdFromTo(decoderYAML, encoderTOML),
+		Action: highlight.Wrap("toml", stdFromTo(decoderYAML, encoderTOML)),
+	},
+	{
+		Name:   "yaml-to-go",
+		Usage:  "generates go struct definitions from a yaml sample",
+		Flags:  goStructFlags("yaml"),
+		Action: toGo(decoderYAML),
 	},
 
 	// XML todo -- needs some special care
@@ -178,6 +227,27 @@ var Commands = []*cli.Command{
 
 	// Other
 
+	{
+		Name:  "idna",
+		Usage: "converts domain names, one per line, between unicode and xn-- punycode by the UTS #46 rules",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "to-ascii",
+				Usage: "converts to the ascii form, eg. xn--mnchen-3ya.de",
+			},
+			&cli.BoolFlag{
+				Name:  "to-unicode",
+				Usage: "converts to the unicode form, eg. münchen.de",
+			},
+			&cli.StringFlag{
+				Name:  "profile",
+				Value: "lookup",
+				Usage: "lookup, registration with stricter validation, or display which does not fail on invalid labels",
+			},
+		},
+		Action: idnaConvert,
+	},
+
 	{
 		Name:    "int-to-string",
 		Aliases: []string{"i2s"},
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/csv"
 	"fmt"
+	"github.com/crholm/iop/highlight"
 	"github.com/modfin/henry/mapz"
 	"github.com/modfin/henry/slicez"
 	"github.com/urfave/cli/v3"
@@ -156,7 +157,13 @@ func toCsv(decode func(r io.Reader) decoder) func(ctx context.Context, c *cli.Co
 			return nil
 		}
 
-		writer := csv.NewWriter(out)
+		p, err := highlight.New(c)
+		if err != nil {
+			return err
+		}
+
+		buf := &bytes.Buffer{}
+		writer := csv.NewWriter(buf)
 
 		switch c.String("delimiter") {
 		case "\\t":
@@ -196,7 +203,8 @@ func toCsv(decode func(r io.Reader) decoder) func(ctx context.Context, c *cli.Co
 
 		writer.Flush()
 
-		return nil
+		_, err = io.WriteString(out, p.CSV(buf.String(), writer.Comma))
+		return err
 	}
 
 }
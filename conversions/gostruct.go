@@ -0,0 +1,445 @@
+package conversions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli/v3"
+	"go/format"
+	"go/token"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type goKind int
+
+const (
+	goNull goKind = iota
+	goBool
+	goInt
+	goFloat
+	goString
+	goTime
+	goLocalDate
+	goLocalTime
+	goLocalDateTime
+	goStruct
+	goSlice
+	goAny
+)
+
+// goType is the inferred shape of a value, merged across all samples seen at the same position
+type goType struct {
+	kind     goKind
+	nullable bool
+
+	// struct
+	seen   int
+	fields map[string]*goField
+
+	// slice
+	elem *goType
+}
+
+type goField struct {
+	typ  *goType
+	seen int
+}
+
+func goStructFlags(tag string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    "package",
+			Aliases: []string{"p"},
+			Value:   "main",
+			Usage:   "package name of the generated file",
+		},
+		&cli.StringFlag{
+			Name:    "type-name",
+			Aliases: []string{"t"},
+			Value:   "Root",
+			Usage:   "name of the top level type",
+		},
+		&cli.StringFlag{
+			Name:  "tags",
+			Value: tag,
+			Usage: "comma separated list of struct tags to emit, eg. json,yaml,toml",
+		},
+		&cli.StringFlag{
+			Name:  "optional",
+			Value: "omitempty",
+			Usage: "how fields missing in some samples are emitted, [omitempty | pointer]",
+		},
+	}
+}
+
+func toGo(decode func(r io.Reader) decoder) cli.ActionFunc {
+	return func(ctx context.Context, c *cli.Command) error {
+		in := c.Reader
+		out := c.Writer
+
+		pkg := c.String("package")
+		if !token.IsIdentifier(pkg) || pkg == "_" {
+			return fmt.Errorf("invalid package name, expected a go identifier: %s", pkg)
+		}
+
+		optional := c.String("optional")
+		if optional != "omitempty" && optional != "pointer" {
+			return fmt.Errorf("invalid optional mode, expected omitempty or pointer: %s", optional)
+		}
+
+		dec := decode(in)
+		if d, ok := dec.(*json.Decoder); ok {
+			d.UseNumber()
+		}
+
+		var item any
+		err := dec.Decode(&item)
+		if err != nil {
+			return fmt.Errorf("failed to decode: %s", err)
+		}
+
+		var tags []string
+		for _, tag := range strings.Split(c.String("tags"), ",") {
+			tag = strings.TrimSpace(tag)
+			if tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+
+		g := &goGenerator{
+			tags:     tags,
+			pointers: optional == "pointer",
+			names:    map[string]bool{},
+		}
+		src, err := g.generate(pkg, goIdentifier(c.String("type-name")), inferGoType(item))
+		if err != nil {
+			return err
+		}
+
+		_, err = out.Write(src)
+		return err
+	}
+}
+
+func inferGoType(v any) *goType {
+	switch v := v.(type) {
+	case nil:
+		return &goType{kind: goNull}
+	case bool:
+		return &goType{kind: goBool}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return &goType{kind: goInt}
+		}
+		return &goType{kind: goFloat}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return &goType{kind: goInt}
+	case float32, float64:
+		return &goType{kind: goFloat}
+	case string:
+		return &goType{kind: goString}
+	case time.Time:
+		return &goType{kind: goTime}
+	// toml local dates and times have no zone, go-toml can not decode them into time.Time
+	case toml.LocalDate:
+		return &goType{kind: goLocalDate}
+	case toml.LocalTime:
+		return &goType{kind: goLocalTime}
+	case toml.LocalDateTime:
+		return &goType{kind: goLocalDateTime}
+	case map[string]any:
+		t := &goType{kind: goStruct, seen: 1, fields: map[string]*goField{}}
+		for k, vv := range v {
+			t.fields[k] = &goField{typ: inferGoType(vv), seen: 1}
+		}
+		return t
+	case map[any]any:
+		m := map[string]any{}
+		for k, vv := range v {
+			m[fmt.Sprintf("%v", k)] = vv
+		}
+		return inferGoType(m)
+	case []any:
+		var elem *goType
+		for _, vv := range v {
+			elem = mergeGoType(elem, inferGoType(vv))
+		}
+		if elem == nil {
+			elem = &goType{kind: goAny}
+		}
+		return &goType{kind: goSlice, elem: elem}
+	case []map[string]any:
+		var items []any
+		for _, vv := range v {
+			items = append(items, vv)
+		}
+		return inferGoType(items)
+	default:
+		return &goType{kind: goAny}
+	}
+}
+
+func mergeGoType(a, b *goType) *goType {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.kind == goNull {
+		b.nullable = true
+		return b
+	}
+	if b.kind == goNull {
+		a.nullable = true
+		return a
+	}
+
+	nullable := a.nullable || b.nullable
+	switch {
+	case a.kind == b.kind && a.kind == goStruct:
+		for k, f := range b.fields {
+			if af, ok := a.fields[k]; ok {
+				af.typ = mergeGoType(af.typ, f.typ)
+				af.seen += f.seen
+				continue
+			}
+			a.fields[k] = f
+		}
+		a.seen += b.seen
+	case a.kind == b.kind && a.kind == goSlice:
+		if a.elem.kind == goAny && b.elem.kind != goAny {
+			a.elem = b.elem
+		} else if b.elem.kind != goAny {
+			a.elem = mergeGoType(a.elem, b.elem)
+		}
+	case a.kind == b.kind:
+	case (a.kind == goInt && b.kind == goFloat) || (a.kind == goFloat && b.kind == goInt):
+		a = &goType{kind: goFloat}
+	default:
+		a = &goType{kind: goAny}
+	}
+	a.nullable = nullable
+	return a
+}
+
+type goGenerator struct {
+	tags     []string
+	pointers bool
+	names    map[string]bool
+	usesTime bool
+	usesTOML bool
+	decls    []string
+}
+
+func (g *goGenerator) generate(pkg string, name string, root *goType) ([]byte, error) {
+	g.names[name] = true
+	var decl string
+	switch root.kind {
+	case goStruct:
+		decl = g.structDecl(name, root)
+	case goSlice:
+		decl = fmt.Sprintf("type %s %s", name, g.typeName(name, root, false))
+	default:
+		decl = fmt.Sprintf("type %s %s", name, g.typeName(name+"Value", root, false))
+	}
+	g.decls = append([]string{decl}, g.decls...)
+
+	buf := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(buf, "package %s\n\n", pkg)
+	var imports []string
+	if g.usesTime {
+		imports = append(imports, `"time"`)
+	}
+	if g.usesTOML {
+		imports = append(imports, `"github.com/pelletier/go-toml/v2"`)
+	}
+	switch len(imports) {
+	case 0:
+	case 1:
+		_, _ = fmt.Fprintf(buf, "import %s\n\n", imports[0])
+	default:
+		_, _ = fmt.Fprintf(buf, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	buf.WriteString(strings.Join(g.decls, "\n\n"))
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated source: %s", err)
+	}
+	return src, nil
+}
+
+func (g *goGenerator) structDecl(name string, t *goType) string {
+	keys := make([]string, 0, len(t.fields))
+	for k := range t.fields {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := goIdentifier(keys[i]), goIdentifier(keys[j])
+		if a == b {
+			return keys[i] < keys[j]
+		}
+		return a < b
+	})
+
+	used := map[string]bool{}
+	buf := &strings.Builder{}
+	_, _ = fmt.Fprintf(buf, "type %s struct {\n", name)
+	for _, k := range keys {
+		f := t.fields[k]
+		optional := f.seen < t.seen
+
+		fieldName := goIdentifier(k)
+		for i := 2; used[fieldName]; i++ {
+			fieldName = fmt.Sprintf("%s%d", goIdentifier(k), i)
+		}
+		used[fieldName] = true
+
+		// omitempty has no effect on struct values, so optional structs are always pointers
+		pointer := optional && (g.pointers || f.typ.kind == goStruct)
+		typeName := g.typeName(fieldName, f.typ, pointer)
+
+		var tags []string
+		for _, tag := range g.tags {
+			value := k
+			if optional || f.typ.nullable {
+				value += ",omitempty"
+			}
+			tags = append(tags, fmt.Sprintf("%s:%q", tag, value))
+		}
+
+		_, _ = fmt.Fprintf(buf, "\t%s %s", fieldName, typeName)
+		if len(tags) > 0 {
+			_, _ = fmt.Fprintf(buf, " `%s`", strings.Join(tags, " "))
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// typeName returns the go type expression of t, declaring any nested struct types under a name derived from hint
+func (g *goGenerator) typeName(hint string, t *goType, pointer bool) string {
+	var name string
+	switch t.kind {
+	case goBool:
+		name = "bool"
+	case goInt:
+		name = "int64"
+	case goFloat:
+		name = "float64"
+	case goString:
+		name = "string"
+	case goTime:
+		g.usesTime = true
+		name = "time.Time"
+	case goLocalDate:
+		g.usesTOML = true
+		name = "toml.LocalDate"
+	case goLocalTime:
+		g.usesTOML = true
+		name = "toml.LocalTime"
+	case goLocalDateTime:
+		g.usesTOML = true
+		name = "toml.LocalDateTime"
+	case goStruct:
+		name = g.uniqueName(hint)
+		g.decls = append(g.decls, "")
+		i := len(g.decls) - 1
+		g.decls[i] = g.structDecl(name, t)
+	case goSlice:
+		return "[]" + g.typeName(singular(hint), t.elem, false)
+	default:
+		return "any"
+	}
+	if pointer || t.nullable {
+		return "*" + name
+	}
+	return name
+}
+
+func (g *goGenerator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.names[unique] = true
+	return unique
+}
+
+var goInitialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true, "DNS": true, "EOF": true,
+	"GUID": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true,
+	"LHS": true, "QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true, "SMTP": true,
+	"SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true, "UDP": true, "UI": true,
+	"UID": true, "UUID": true, "URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true,
+	"XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// goIdentifier turns a key such as "user_id", "user-id" or "userId" into an exported go identifier, eg. UserID
+func goIdentifier(key string) string {
+	var words []string
+	var word []rune
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	runes := []rune(key)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) && len(word) > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	buf := &strings.Builder{}
+	for _, w := range words {
+		upper := strings.ToUpper(w)
+		if goInitialisms[upper] {
+			buf.WriteString(upper)
+			continue
+		}
+		rs := []rune(strings.ToLower(w))
+		rs[0] = unicode.ToUpper(rs[0])
+		buf.WriteString(string(rs))
+	}
+
+	name := buf.String()
+	if name == "" {
+		return "Field"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		return "Field" + name
+	}
+	return name
+}
+
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "ss"):
+		return name + "Item"
+	case strings.HasSuffix(name, "s") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+	return name + "Item"
+}
@@ -0,0 +1,167 @@
+package conversions
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestToGo(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		decode  func(r io.Reader) decoder
+		args    []string
+		lines   []string
+		wantErr bool
+	}{
+		{
+			name:   "json object",
+			input:  `{"id": 1, "user_name": "x", "score": 1.5, "ok": true}`,
+			decode: decoderJSON,
+			args:   []string{"--tags=json"},
+			lines: []string{
+				"package main",
+				"type Root struct {",
+				"ID       int64   `json:\"id\"`",
+				"UserName string  `json:\"user_name\"`",
+				"Score    float64 `json:\"score\"`",
+				"Ok       bool    `json:\"ok\"`",
+			},
+		},
+		{
+			name:   "json array merges elements",
+			input:  `{"items": [{"a": 1, "b": "x"}, {"a": 2.5, "c": {"d": null}}]}`,
+			decode: decoderJSON,
+			args:   []string{"--tags=json"},
+			lines: []string{
+				"Items []Item `json:\"items\"`",
+				"type Item struct {",
+				"A float64 `json:\"a\"`",
+				"B string  `json:\"b,omitempty\"`",
+				"C *C      `json:\"c,omitempty\"`",
+			},
+		},
+		{
+			name:   "optional pointers",
+			input:  `[{"a": 1}, {"b": "x"}]`,
+			decode: decoderJSON,
+			args:   []string{"--tags=json", "--optional=pointer", "--type-name=list", "--package=model"},
+			lines: []string{
+				"package model",
+				"type List []ListItem",
+				"A *int64  `json:\"a,omitempty\"`",
+				"B *string `json:\"b,omitempty\"`",
+			},
+		},
+		{
+			name:   "yaml with multiple tags",
+			input:  "server:\n  port: 8080\n",
+			decode: decoderYAML,
+			args:   []string{"--tags=yaml,json"},
+			lines: []string{
+				"Server Server `yaml:\"server\" json:\"server\"`",
+				"Port int64 `yaml:\"port\" json:\"port\"`",
+			},
+		},
+		{
+			name:   "toml with time",
+			input:  "released = 1979-05-27T07:32:00Z\n",
+			decode: decoderTOML,
+			args:   []string{"--tags=toml"},
+			lines: []string{
+				`import "time"`,
+				"Released time.Time `toml:\"released\"`",
+			},
+		},
+		{
+			name:   "toml with local dates and times",
+			input:  "day = 1979-05-27\nat = 07:32:00\nlocal = 1979-05-27T07:32:00\n",
+			decode: decoderTOML,
+			args:   []string{"--tags=toml"},
+			lines: []string{
+				`import "github.com/pelletier/go-toml/v2"`,
+				"At    toml.LocalTime     `toml:\"at\"`",
+				"Day   toml.LocalDate     `toml:\"day\"`",
+				"Local toml.LocalDateTime `toml:\"local\"`",
+			},
+		},
+		{
+			name:    "invalid optional mode",
+			input:   `{}`,
+			decode:  decoderJSON,
+			args:    []string{"--optional=maybe"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid package name",
+			input:   `{}`,
+			decode:  decoderJSON,
+			args:    []string{"--package=my-pkg"},
+			wantErr: true,
+		},
+		{
+			name:    "keyword as package name",
+			input:   `{}`,
+			decode:  decoderJSON,
+			args:    []string{"--package=func"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			input:   `{"a":`,
+			decode:  decoderJSON,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := strings.NewReader(tt.input)
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: in,
+				Writer: out,
+				Flags:  goStructFlags("json"),
+				Action: toGo(tt.decode),
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("toGo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			lines := map[string]bool{}
+			for _, line := range strings.Split(out.String(), "\n") {
+				lines[strings.TrimSpace(line)] = true
+			}
+			for _, s := range tt.lines {
+				if !lines[s] {
+					t.Errorf("toGo() output missing the line %q, got\n%s", s, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGoIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"id":         "ID",
+		"user_id":    "UserID",
+		"userId":     "UserID",
+		"HTTPServer": "HTTPServer",
+		"api-url":    "APIURL",
+		"first name": "FirstName",
+		"2fa":        "Field2fa",
+		"":           "Field",
+		"ÅrsRapport": "ÅrsRapport",
+	}
+	for in, expected := range tests {
+		if got := goIdentifier(in); got != expected {
+			t.Errorf("goIdentifier(%q) got = %v, want %v", in, got, expected)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package conversions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/net/idna"
+	"io"
+	"strings"
+)
+
+// idnaProfiles are the UTS #46 profiles, lookup for resolving names, registration for the stricter rules of
+// registering them and display, which leaves names with invalid labels as they are
+var idnaProfiles = map[string]*idna.Profile{
+	"lookup":       idna.Lookup,
+	"registration": idna.Registration,
+	"display":      idna.Display,
+}
+
+// idnaConvert converts domain names, one per line, between their unicode and ascii, xn--, forms
+func idnaConvert(ctx context.Context, c *cli.Command) error {
+	toASCII, toUnicode := c.Bool("to-ascii"), c.Bool("to-unicode")
+	if toASCII == toUnicode {
+		return errors.New("use either --to-ascii or --to-unicode")
+	}
+	profile, ok := idnaProfiles[c.String("profile")]
+	if !ok {
+		return fmt.Errorf("unknown profile %s, expected lookup, registration or display", c.String("profile"))
+	}
+	convert := profile.ToUnicode
+	if toASCII {
+		convert = profile.ToASCII
+	}
+
+	b, err := io.ReadAll(c.Reader)
+	if err != nil {
+		return err
+	}
+	var res []string
+	for _, domain := range strings.Split(strings.TrimRight(string(b), "\r\n"), "\n") {
+		domain = strings.TrimSpace(domain)
+		if domain == "" {
+			res = append(res, "")
+			continue
+		}
+		s, err := convert(domain)
+		if err != nil && profile == idna.Display {
+			s = domain
+		} else if err != nil {
+			return fmt.Errorf("%s: %w", domain, err)
+		}
+		res = append(res, s)
+	}
+	_, err = io.WriteString(c.Writer, strings.Join(res, "\n"))
+	return err
+}
@@ -0,0 +1,61 @@
+package conversions
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestIdnaConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+		wantErr  string
+	}{
+		{name: "to ascii", args: []string{"--to-ascii"}, input: "München.DE\nbücher.example\n", expected: "xn--mnchen-3ya.de\nxn--bcher-kva.example"},
+		{name: "ascii stays", args: []string{"--to-ascii"}, input: "example.com", expected: "example.com"},
+		{name: "to unicode", args: []string{"--to-unicode"}, input: "xn--mnchen-3ya.de", expected: "münchen.de"},
+		{name: "blank lines are kept", args: []string{"--to-unicode"}, input: "a.com\n\nxn--bcher-kva.com", expected: "a.com\n\nbücher.com"},
+		{name: "mapping", args: []string{"--to-ascii"}, input: "ＥＸＡＭＰＬＥ.com", expected: "example.com"},
+		{name: "invalid label", args: []string{"--to-unicode"}, input: "xn--a.com", wantErr: `xn--a.com: idna: invalid label`},
+		{name: "disallowed rune", args: []string{"--to-ascii"}, input: "a b.com", wantErr: "a b.com: idna: disallowed rune U+0020"},
+		{name: "display does not fail", args: []string{"--to-unicode", "--profile", "display"}, input: "xn--a.com", expected: "xn--a.com"},
+		{name: "unknown profile", args: []string{"--to-ascii", "--profile", "strict"}, input: "a.com", wantErr: "unknown profile strict"},
+		{name: "no direction", input: "a.com", wantErr: "use either --to-ascii or --to-unicode"},
+		{name: "both directions", args: []string{"--to-ascii", "--to-unicode"}, input: "a.com", wantErr: "use either --to-ascii or --to-unicode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "to-ascii"},
+					&cli.BoolFlag{Name: "to-unicode"},
+					&cli.StringFlag{Name: "profile", Value: "lookup"},
+				},
+				Action: idnaConvert,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("idnaConvert() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("idnaConvert() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("idnaConvert() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
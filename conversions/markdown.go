@@ -0,0 +1,115 @@
+package conversions
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"github.com/crholm/iop/highlight"
+	"github.com/urfave/cli/v3"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var markdownDelimiterRow = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+
+// splitMarkdownRow splits a table row into its cells, keeping escaped pipes inside cells
+func splitMarkdownRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	cell := &strings.Builder{}
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, cell.String())
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	cells = append(cells, cell.String())
+
+	for i, c := range cells {
+		cells[i] = strings.NewReplacer("<br>", "\n", "<br/>", "\n", "<br />", "\n").Replace(strings.TrimSpace(c))
+	}
+	return cells
+}
+
+// parseMarkdownTable returns the rows, header included, of the first table in a markdown document. As in GitHub
+// flavored markdown the table ends at the first empty line
+func parseMarkdownTable(r io.Reader) ([][]string, error) {
+	scanner := bufio.NewScanner(r)
+	var prev string
+	var rows [][]string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case rows == nil && markdownDelimiterRow.MatchString(line) && strings.Contains(prev, "|"):
+			rows = [][]string{splitMarkdownRow(prev)}
+		case rows != nil && line != "":
+			rows = append(rows, splitMarkdownRow(line))
+		case rows != nil:
+			return rows, nil
+		}
+		prev = line
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		return nil, errors.New("no markdown table found, expected a header row followed by a delimiter row, eg. | --- |")
+	}
+	return rows, nil
+}
+
+func markdownToCsv(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	rows, err := parseMarkdownTable(in)
+	if err != nil {
+		return err
+	}
+
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	writer := csv.NewWriter(buf)
+	switch c.String("delimiter") {
+	case "\\t":
+		writer.Comma = '\t'
+	case "":
+		writer.Comma = ','
+	default:
+		writer.Comma = rune(c.String("delimiter")[0])
+	}
+
+	// every row gets as many cells as the header
+	for _, row := range rows {
+		row = append(row, make([]string, max(len(rows[0])-len(row), 0))...)
+		err = writer.Write(row[:len(rows[0])])
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(out, p.CSV(buf.String(), writer.Comma))
+	return err
+}
@@ -0,0 +1,57 @@
+package conversions
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToCsv(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "table",
+			input:    "| name | age |\n| ---- | --: |\n| Åsa  |  30 |\n",
+			expected: "name,age\nÅsa,30\n",
+		},
+		{
+			name:     "first table of a document",
+			input:    "# Title\n\nname | note\n:--|:-:\na | x \\| y<br>z\nb\n\n| c |\n| - |\n| d |\n",
+			expected: "name,note\na,\"x | y\nz\"\nb,\n",
+		},
+		{
+			name:    "no table",
+			input:   "just | text\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "delimiter"},
+				},
+				Action: markdownToCsv,
+			}
+
+			err := cmd.Run(context.Background(), []string{""})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("markdownToCsv() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && out.String() != tt.expected {
+				t.Errorf("markdownToCsv() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package conversions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// queryOf returns the query of a full url, or the input itself if it is only a query string
+func queryOf(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		s = s[i+1:]
+	}
+	if i := strings.IndexByte(s, '#'); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// urlToJson converts a query string into an object, keys that are repeated become arrays
+func urlToJson(ctx context.Context, c *cli.Command) error {
+	b, err := io.ReadAll(c.Reader)
+	if err != nil {
+		return err
+	}
+	query, err := url.ParseQuery(queryOf(string(b)))
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	res := map[string]any{}
+	for k, values := range query {
+		if len(values) == 1 && !c.Bool("arrays") {
+			res[k] = values[0]
+			continue
+		}
+		res[k] = values
+	}
+	return encoderJSON(c.Writer).Encode(res)
+}
+
+func queryValue(key string, v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	}
+	return "", fmt.Errorf("the value of %s can not be a query parameter, only strings, numbers, booleans and arrays of them can", key)
+}
+
+// jsonToUrl converts an object into a query string, arrays become repeated keys
+func jsonToUrl(ctx context.Context, c *cli.Command) error {
+	d := json.NewDecoder(c.Reader)
+	d.UseNumber()
+	var obj map[string]any
+	if err := d.Decode(&obj); err != nil {
+		return fmt.Errorf("expected a json object: %w", err)
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, k := range keys {
+		values, ok := obj[k].([]any)
+		if !ok {
+			values = []any{obj[k]}
+		}
+		for _, v := range values {
+			s, err := queryValue(k, v)
+			if err != nil {
+				return err
+			}
+			params = append(params, url.QueryEscape(k)+"="+url.QueryEscape(s))
+		}
+	}
+	_, err := io.WriteString(c.Writer, strings.Join(params, "&"))
+	return err
+}
@@ -0,0 +1,80 @@
+package conversions
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestUrlToJson(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "query", input: "b=2&a=1&a=%C3%A4&c", expected: `{"a":["1","ä"],"b":"2","c":""}`},
+		{name: "full url", input: "https://example.com/p?q=a+b#frag\n", expected: `{"q":"a b"}`},
+		{name: "arrays", args: []string{"--arrays"}, input: "?q=x", expected: `{"q":["x"]}`},
+		{name: "empty", input: "", expected: `{}`},
+		{name: "invalid escape", input: "q=%zz", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags:  []cli.Flag{&cli.BoolFlag{Name: "arrays"}},
+				Action: urlToJson,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("urlToJson() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && strings.TrimSpace(out.String()) != tt.expected {
+				t.Errorf("urlToJson() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestJsonToUrl(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "object", input: `{"q":"a b","n":[1,2.5],"ok":true,"z":null}`, expected: "n=1&n=2.5&ok=true&q=a+b&z="},
+		{name: "escaped keys", input: `{"a&b":"c=d"}`, expected: "a%26b=c%3Dd"},
+		{name: "large numbers are kept", input: `{"id":12345678901234567890}`, expected: "id=12345678901234567890"},
+		{name: "empty", input: `{}`, expected: ""},
+		{name: "nested object", input: `{"a":{"b":1}}`, wantErr: true},
+		{name: "nested array", input: `{"a":[[1]]}`, wantErr: true},
+		{name: "not an object", input: `[1]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+			}
+			err := jsonToUrl(context.Background(), cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("jsonToUrl() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("jsonToUrl() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package conversions
 import (
 	"encoding/json"
 	"encoding/xml"
+	"fmt"
 	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/yaml.v3"
 	"io"
+	"path/filepath"
+	"strings"
 )
 
 func decoderJSON(r io.Reader) decoder {
@@ -37,3 +40,125 @@ func decoderYAML(r io.Reader) decoder {
 func encoderYAML(w io.Writer) encoder {
 	return yaml.NewEncoder(w)
 }
+
+// FormatOf guesses the document format of a file from its extension, eg. data.yml gives yaml
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	case ".xml":
+		return "xml"
+	}
+	return ""
+}
+
+// Sniff guesses the document format of b by trying the stricter formats first
+func Sniff(b []byte) string {
+	var item any
+	if json.Unmarshal(b, &item) == nil {
+		return "json"
+	}
+	if toml.Unmarshal(b, &item) == nil {
+		return "toml"
+	}
+	return "yaml"
+}
+
+// Decode reads one document in the given format from r. Maps are always returned as map[string]any and json numbers
+// as json.Number
+func Decode(format string, r io.Reader) (any, error) {
+	var dec decoder
+	switch format {
+	case "json":
+		// numbers are kept as json.Number, so that integers stay integers and large ones keep their precision
+		d := json.NewDecoder(r)
+		d.UseNumber()
+		dec = d
+	case "yaml", "yml":
+		dec = decoderYAML(r)
+	case "toml":
+		dec = decoderTOML(r)
+	default:
+		return nil, fmt.Errorf("unsupported input format: %q", format)
+	}
+
+	var item any
+	err := dec.Decode(&item)
+	if err != nil {
+		return nil, err
+	}
+	return normalize(item), nil
+}
+
+// Encode writes v to w in the given format
+func Encode(format string, w io.Writer, v any) error {
+	var enc encoder
+	switch format {
+	case "json":
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		enc = e
+	case "yaml", "yml":
+		enc = encoderYAML(w)
+	case "toml":
+		enc = encoderTOML(w)
+	default:
+		return fmt.Errorf("unsupported output format: %q", format)
+	}
+	if format != "json" {
+		v = resolveNumbers(v)
+	}
+	return enc.Encode(v)
+}
+
+// resolveNumbers replaces json.Number with int64, or float64 if it is not an integer, as other formats would
+// otherwise write them as strings
+func resolveNumbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any:
+		for k, vv := range v {
+			v[k] = resolveNumbers(vv)
+		}
+		return v
+	case []any:
+		for i, vv := range v {
+			v[i] = resolveNumbers(vv)
+		}
+		return v
+	}
+	return v
+}
+
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[any]any:
+		m := map[string]any{}
+		for k, vv := range v {
+			m[fmt.Sprintf("%v", k)] = normalize(vv)
+		}
+		return m
+	case map[string]any:
+		for k, vv := range v {
+			v[k] = normalize(vv)
+		}
+		return v
+	case []any:
+		for i, vv := range v {
+			v[i] = normalize(vv)
+		}
+		return v
+	}
+	return v
+}
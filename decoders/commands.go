@@ -1,13 +1,32 @@
 package decoders
 
 import (
+	"github.com/crholm/iop/utils"
 	"github.com/urfave/cli/v3"
 )
 
 var Commands = []*cli.Command{
 	{
-		Name:   "url",
-		Usage:  "decodes a url query string",
+		Name:  "url",
+		Usage: "decodes a url encoded string, or parses a full url with --parse",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "mode",
+				Aliases: []string{"m"},
+				Value:   "query",
+				Usage:   "query, decoding + as a space, or path, fragment or userinfo, keeping +",
+			},
+			&cli.BoolFlag{
+				Name:    "parse",
+				Aliases: []string{"p"},
+				Usage:   "split a url into scheme, user, host, port, path segments, query and fragment",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "json",
+				Usage: "output format of --parse, [json | yaml | toml]",
+			},
+		},
 		Action: decodeURL,
 	},
 	{
@@ -26,6 +45,10 @@ var Commands = []*cli.Command{
 				Aliases: []string{"u"},
 				Usage:   "url encoding",
 			},
+			&cli.BoolFlag{
+				Name:  "strict",
+				Usage: "only accept padded input without white space, in the chosen alphabet",
+			},
 		},
 		Action: decodeBase64,
 	},
@@ -38,15 +61,106 @@ var Commands = []*cli.Command{
 				Name:  "hex",
 				Usage: "hex version",
 			},
+			&cli.BoolFlag{
+				Name:  "strict",
+				Usage: "only accept padded input without white space, in upper case",
+			},
 		},
 		Action: decodeBase32,
 	},
+	{
+		Name:    "b58",
+		Aliases: []string{"base58"},
+		Usage:   "decodes a base58 string",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "alphabet",
+				Value: "bitcoin",
+				Usage: "bitcoin, flickr or ripple",
+			},
+		},
+		Action: decodeBase58,
+	},
+	{
+		Name:    "b58check",
+		Aliases: []string{"base58check"},
+		Usage:   "decodes a base58check string, validating its checksum",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "alphabet",
+				Value: "bitcoin",
+				Usage: "bitcoin, flickr or ripple",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "raw",
+				Usage: "output format, raw for the payload bytes with the version byte written to std err, or [json | yaml | toml | xml ] for the version byte and hex payload",
+			},
+		},
+		Action: decodeBase58Check,
+	},
+	{
+		Name:    "b62",
+		Aliases: []string{"base62"},
+		Usage:   "decodes a base62 string",
+		Action:  decodeBase62,
+	},
+	{
+		Name:    "a85",
+		Aliases: []string{"ascii85"},
+		Usage:   "decodes an ascii85 string, with or without <~ ~> delimiters",
+		Action:  decodeAscii85,
+	},
+	{
+		Name:   "z85",
+		Usage:  "decodes a z85 string, the zeromq variant",
+		Action: decodeBase85(utils.Z85),
+	},
+	{
+		Name:    "b85",
+		Aliases: []string{"base85"},
+		Usage:   "decodes a base85 string with the RFC 1924 alphabet, as git does",
+		Action:  decodeBase85(utils.B85),
+	},
+	{
+		Name:    "b45",
+		Aliases: []string{"base45"},
+		Usage:   "decodes a base45 string, RFC 9285",
+		Action:  decodeBase45,
+	},
+	{
+		Name:    "b36",
+		Aliases: []string{"base36"},
+		Usage:   "decodes a base36 string, in either case",
+		Action:  decodeBase36,
+	},
+	{
+		Name:  "bech32",
+		Usage: "decodes a bech32 or bech32m string, validating its checksum",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "segwit",
+				Usage: "the first value is a witness version, as in bitcoin addresses. Version 0 has to use bech32 and 1-16 bech32m",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "raw",
+				Usage: "output format, raw for the data bytes, or [json | yaml | toml | xml ] for the hrp, variant and hex data",
+			},
+		},
+		Action: decodeBech32,
+	},
 	{
 		Name:    "hex",
 		Aliases: []string{"0x"},
-		Usage:   "decodes a hex string",
+		Usage:   "decodes a hex string, ignoring white space, colons, commas and 0x prefixes",
 		Action:  decodeHex,
 	},
+	{
+		Name:   "hexdump",
+		Usage:  "decodes xxd, hexdump -C, od -x and wireshark dumps",
+		Action: decodeHexdump,
+	},
 	{
 		Name:   "jwt",
 		Usage:  "decodes a jwt token",
@@ -70,4 +184,59 @@ var Commands = []*cli.Command{
 		Usage:  "decodes mime headers RFC 2047, ascii representations of encoded words",
 		Action: decodeMIME,
 	},
+	{
+		Name:    "datauri",
+		Aliases: []string{"data-uri"},
+		Usage:   "decodes a data: uri, RFC 2397, into its payload, converting text with a charset to utf-8",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "raw",
+				Usage: "output format, raw for the payload, or [json | yaml | toml | xml ] for the media type, parameters and charset",
+			},
+		},
+		Action: decodeDataURI,
+	},
+	{
+		Name:   "punycode",
+		Usage:  "decodes a punycode domain label, RFC 3492, with or without the xn-- prefix",
+		Action: decodePunycode,
+	},
+	{
+		Name:    "qp",
+		Aliases: []string{"quoted-printable"},
+		Usage:   "decodes quoted-printable mime bodies, RFC 2045",
+		Action:  decodeQuotedPrintable,
+	},
+	{
+		Name:    "email",
+		Aliases: []string{"eml"},
+		Usage:   "parses an RFC 5322 email into json, with decoded headers, the multipart tree, utf-8 bodies and attachment metadata",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "extract-dir",
+				Aliases: []string{"x"},
+				Usage:   "save attachments to the directory",
+			},
+		},
+		Action: decodeEmail,
+	},
+	{
+		Name:   "html",
+		Usage:  "unescapes html entities, named, decimal and hex",
+		Action: decodeHTML,
+	},
+	{
+		Name:  "literal",
+		Usage: "unescapes a string literal or byte array of a programming language",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "lang",
+				Aliases: []string{"l"},
+				Value:   "go",
+				Usage:   "go, c, python, js, java, json, shell or sql",
+			},
+		},
+		Action: decodeLiteral,
+	},
 }
@@ -0,0 +1,225 @@
+package decoders
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/crholm/iop/highlight"
+	"github.com/urfave/cli/v3"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// emailPart is a node of the mime tree of an email. Text bodies are decoded to utf-8, other parts are attachments
+// described by their metadata. Headers are only kept for the message itself and nested message/rfc822 parts
+type emailPart struct {
+	Headers     map[string][]string `json:"headers,omitempty"`
+	ContentType string              `json:"content_type"`
+	Charset     string              `json:"charset,omitempty"`
+	Encoding    string              `json:"transfer_encoding,omitempty"`
+	Disposition string              `json:"disposition,omitempty"`
+	Filename    string              `json:"filename,omitempty"`
+	Size        int                 `json:"size,omitempty"`
+	Body        string              `json:"body,omitempty"`
+	Saved       string              `json:"saved,omitempty"`
+	Parts       []emailPart         `json:"parts,omitempty"`
+	Message     *emailPart          `json:"message,omitempty"`
+}
+
+type emailParser struct {
+	words       *mime.WordDecoder
+	extractDir  string
+	attachments int
+}
+
+func (e *emailParser) message(r io.Reader, section string) (emailPart, error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return emailPart{}, fmt.Errorf("failed to read message: %w", err)
+	}
+	headers := map[string][]string{}
+	for k, values := range msg.Header {
+		for _, v := range values {
+			if decoded, err := e.words.DecodeHeader(v); err == nil {
+				v = decoded
+			}
+			headers[k] = append(headers[k], v)
+		}
+	}
+	part, err := e.part(msg.Header.Get, msg.Body, section)
+	part.Headers = headers
+	return part, err
+}
+
+// part decodes a part body by its headers, section is the imap like number of the part, eg. 1.2, for errors
+func (e *emailParser) part(header func(string) string, body io.Reader, section string) (emailPart, error) {
+	mediaType, params, err := mime.ParseMediaType(header("Content-Type"))
+	if err != nil {
+		// RFC 2045, parts without a valid content type are plain us-ascii text
+		mediaType, params = "text/plain", map[string]string{"charset": "us-ascii"}
+	}
+	res := emailPart{
+		ContentType: mediaType,
+		Charset:     params["charset"],
+		Encoding:    strings.ToLower(strings.TrimSpace(header("Content-Transfer-Encoding"))),
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for i := 1; ; i++ {
+			p, err := mr.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return res, fmt.Errorf("%s: %w", partName(section), err)
+			}
+			child, err := e.part(p.Header.Get, p, subSection(section, i))
+			if err != nil {
+				return res, err
+			}
+			res.Parts = append(res.Parts, child)
+		}
+		return res, nil
+	}
+
+	switch res.Encoding {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return res, fmt.Errorf("%s: failed to decode %s: %w", partName(section), res.Encoding, err)
+	}
+	res.Size = len(b)
+
+	disposition, dparams, _ := mime.ParseMediaType(header("Content-Disposition"))
+	res.Disposition = disposition
+	res.Filename = dparams["filename"]
+	if res.Filename == "" {
+		res.Filename = params["name"]
+	}
+	if decoded, err := e.words.DecodeHeader(res.Filename); err == nil {
+		res.Filename = decoded
+	}
+
+	if mediaType == "message/rfc822" && disposition != "attachment" {
+		nested, err := e.message(bytes.NewReader(b), section)
+		if err != nil {
+			return res, err
+		}
+		res.Message = &nested
+		return res, nil
+	}
+
+	if strings.HasPrefix(mediaType, "text/") && disposition != "attachment" {
+		r, err := charsetReader(res.Charset, bytes.NewReader(b))
+		if err != nil {
+			return res, err
+		}
+		text, err := io.ReadAll(r)
+		if err != nil {
+			return res, fmt.Errorf("%s: failed to convert %s to utf-8: %w", partName(section), res.Charset, err)
+		}
+		res.Body = string(text)
+		return res, nil
+	}
+
+	if e.extractDir != "" {
+		res.Saved, err = e.save(res, b)
+		if err != nil {
+			return res, fmt.Errorf("%s: %w", partName(section), err)
+		}
+	}
+	return res, nil
+}
+
+// save writes an attachment to the extract dir, without overwriting files. Only the base of the file name is used,
+// so that names like ../../x can not write outside of the directory
+func (e *emailParser) save(part emailPart, b []byte) (string, error) {
+	e.attachments++
+	name := filepath.Base(filepath.FromSlash(strings.ReplaceAll(part.Filename, `\`, "/")))
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		name = "attachment-" + strconv.Itoa(e.attachments)
+		if ext, _ := mime.ExtensionsByType(part.ContentType); len(ext) > 0 {
+			name += ext[0]
+		}
+	}
+	if err := os.MkdirAll(e.extractDir, 0o755); err != nil {
+		return "", err
+	}
+
+	ext := filepath.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		path := filepath.Join(e.extractDir, name)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			name = fmt.Sprintf("%s-%d%s", stem, i, ext)
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		_, err = f.Write(b)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		return path, err
+	}
+}
+
+func partName(section string) string {
+	if section == "" {
+		return "message body"
+	}
+	return "part " + section
+}
+
+func subSection(section string, i int) string {
+	if section == "" {
+		return strconv.Itoa(i)
+	}
+	return section + "." + strconv.Itoa(i)
+}
+
+func decodeEmail(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	e := &emailParser{
+		words:      &mime.WordDecoder{CharsetReader: charsetReader},
+		extractDir: c.String("extract-dir"),
+	}
+	msg, err := e.message(in, "")
+	if err != nil {
+		return fmt.Errorf("failed to parse email: %w", err)
+	}
+
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	// addresses and html bodies are easier to read without escaping < > and &
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(msg); err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, p.JSON(strings.TrimSuffix(buf.String(), "\n")))
+	return err
+}
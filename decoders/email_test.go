@@ -0,0 +1,169 @@
+package decoders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/urfave/cli/v3"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testEmail = "From: =?ISO-8859-1?Q?Andr=E9?= <andre@example.com>\r\n" +
+	"Subject: =?utf-8?B?SGVqIGTDpHIh?=\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=\"XX\"\r\n" +
+	"\r\n" +
+	"--XX\r\n" +
+	"Content-Type: multipart/alternative; boundary=\"YY\"\r\n" +
+	"\r\n" +
+	"--YY\r\n" +
+	"Content-Type: text/plain; charset=iso-8859-1\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Hej d=E4r, en l=E5ng rad som =\r\n" +
+	"forts=E4tter.\r\n" +
+	"--YY\r\n" +
+	"Content-Type: text/html; charset=utf-8\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"PHA+SGVqPC9wPg==\r\n" +
+	"--YY--\r\n" +
+	"--XX\r\n" +
+	"Content-Type: application/pdf\r\n" +
+	"Content-Disposition: attachment; filename=\"../../report.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0xLjQK\r\n" +
+	"--XX\r\n" +
+	"Content-Type: image/png\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"iVBORw==\r\n" +
+	"--XX--\r\n"
+
+func runDecodeEmail(t *testing.T, input string, args ...string) (emailPart, error) {
+	out := &bytes.Buffer{}
+	cmd := &cli.Command{
+		Reader: strings.NewReader(input),
+		Writer: out,
+		Flags:  []cli.Flag{&cli.StringFlag{Name: "extract-dir"}},
+		Action: decodeEmail,
+	}
+	err := cmd.Run(context.Background(), append([]string{""}, args...))
+	if err != nil {
+		return emailPart{}, err
+	}
+	var msg emailPart
+	if err := json.Unmarshal(out.Bytes(), &msg); err != nil {
+		t.Fatalf("decodeEmail() output is not json: %v\n%s", err, out.String())
+	}
+	return msg, nil
+}
+
+func TestDecodeEmail(t *testing.T) {
+	dir := t.TempDir()
+	msg, err := runDecodeEmail(t, testEmail, "--extract-dir", dir)
+	if err != nil {
+		t.Fatalf("decodeEmail() error = %v", err)
+	}
+
+	if got := msg.Headers["From"]; len(got) != 1 || got[0] != "André <andre@example.com>" {
+		t.Errorf("decodeEmail() From got = %v", got)
+	}
+	if got := msg.Headers["Subject"]; len(got) != 1 || got[0] != "Hej där!" {
+		t.Errorf("decodeEmail() Subject got = %v", got)
+	}
+	if msg.ContentType != "multipart/mixed" || len(msg.Parts) != 3 {
+		t.Fatalf("decodeEmail() got = %s with %d parts, want multipart/mixed with 3", msg.ContentType, len(msg.Parts))
+	}
+
+	alternative := msg.Parts[0]
+	if len(alternative.Parts) != 2 {
+		t.Fatalf("decodeEmail() alternative got %d parts, want 2", len(alternative.Parts))
+	}
+	if got := alternative.Parts[0].Body; got != "Hej där, en lång rad som fortsätter." {
+		t.Errorf("decodeEmail() text body got = %q", got)
+	}
+	if got := alternative.Parts[1].Body; got != "<p>Hej</p>" {
+		t.Errorf("decodeEmail() html body got = %q", got)
+	}
+
+	tests := []struct {
+		part     emailPart
+		filename string
+		saved    string
+		content  string
+	}{
+		{part: msg.Parts[1], filename: "../../report.pdf", saved: "report.pdf", content: "%PDF-1.4\n"},
+		{part: msg.Parts[2], filename: "", saved: "attachment-2.png", content: "\x89PNG"},
+	}
+	for _, tt := range tests {
+		if tt.part.Filename != tt.filename || tt.part.Size != len(tt.content) || tt.part.Body != "" {
+			t.Errorf("decodeEmail() attachment got = %+v", tt.part)
+		}
+		if tt.part.Saved != filepath.Join(dir, tt.saved) {
+			t.Errorf("decodeEmail() saved got = %v, want %v", tt.part.Saved, filepath.Join(dir, tt.saved))
+		}
+		b, err := os.ReadFile(filepath.Join(dir, tt.saved))
+		if err != nil || string(b) != tt.content {
+			t.Errorf("decodeEmail() saved content got = %q, %v, want %q", b, err, tt.content)
+		}
+	}
+
+	// extracting again does not overwrite
+	msg, err = runDecodeEmail(t, testEmail, "--extract-dir", dir)
+	if err != nil {
+		t.Fatalf("decodeEmail() error = %v", err)
+	}
+	if got, want := msg.Parts[1].Saved, filepath.Join(dir, "report-1.pdf"); got != want {
+		t.Errorf("decodeEmail() saved got = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeEmailSimple(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected emailPart
+		wantErr  bool
+	}{
+		{
+			name:     "no content type",
+			input:    "Subject: hi\r\n\r\nhello\r\n",
+			expected: emailPart{ContentType: "text/plain", Charset: "us-ascii", Size: 7, Body: "hello\r\n"},
+		},
+		{
+			name:     "latin1 body",
+			input:    "Content-Type: text/plain; charset=windows-1252\n\ncaf\xe9",
+			expected: emailPart{ContentType: "text/plain", Charset: "windows-1252", Size: 4, Body: "café"},
+		},
+		{
+			name:    "bad base64",
+			input:   "Content-Transfer-Encoding: base64\n\n!!!!",
+			wantErr: true,
+		},
+		{
+			name:    "no headers",
+			input:   "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := runDecodeEmail(t, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeEmail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			msg.Headers = nil
+			if !tt.wantErr && (msg.ContentType != tt.expected.ContentType || msg.Charset != tt.expected.Charset ||
+				msg.Size != tt.expected.Size || msg.Body != tt.expected.Body) {
+				t.Errorf("decodeEmail() got = %+v, want %+v", msg, tt.expected)
+			}
+		})
+	}
+}
@@ -1,25 +1,27 @@
 package decoders
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"encoding/ascii85"
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
-	"encoding/xml"
 	"errors"
 	"fmt"
+	"github.com/crholm/iop/highlight"
 	"github.com/crholm/iop/utils"
-	"github.com/hokaccha/go-prettyjson"
-	"github.com/pelletier/go-toml/v2"
 	"github.com/rs/xid"
 	"github.com/urfave/cli/v3"
+	"golang.org/x/net/idna"
 	"golang.org/x/text/transform"
-	"gopkg.in/yaml.v3"
+	"html"
 	"io"
 	"math/big"
 	"mime"
+	"mime/quotedprintable"
 	"net/url"
 	"strings"
 	"time"
@@ -33,7 +35,19 @@ func decodeURL(ctx context.Context, c *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	s, err := url.QueryUnescape(string(b))
+	if c.Bool("parse") {
+		return parseURL(c, strings.TrimSpace(string(b)))
+	}
+
+	var s string
+	switch mode := c.String("mode"); mode {
+	case "query", "":
+		s, err = url.QueryUnescape(string(b))
+	case "path", "fragment", "userinfo":
+		s, err = url.PathUnescape(string(b))
+	default:
+		return fmt.Errorf("unknown mode %s, expected path, query, fragment or userinfo", mode)
+	}
 	if err != nil {
 		return err
 	}
@@ -42,6 +56,71 @@ func decodeURL(ctx context.Context, c *cli.Command) error {
 	return err
 }
 
+type parsedURL struct {
+	Scheme   string              `yaml:"scheme,omitempty" toml:"scheme,omitempty" json:"scheme,omitempty"`
+	Opaque   string              `yaml:"opaque,omitempty" toml:"opaque,omitempty" json:"opaque,omitempty"`
+	User     string              `yaml:"user,omitempty" toml:"user,omitempty" json:"user,omitempty"`
+	Password *string             `yaml:"password,omitempty" toml:"password,omitempty" json:"password,omitempty"`
+	Host     string              `yaml:"host,omitempty" toml:"host,omitempty" json:"host,omitempty"`
+	Port     string              `yaml:"port,omitempty" toml:"port,omitempty" json:"port,omitempty"`
+	Path     string              `yaml:"path,omitempty" toml:"path,omitempty" json:"path,omitempty"`
+	Segments []string            `yaml:"segments,omitempty" toml:"segments,omitempty" json:"segments,omitempty"`
+	Query    map[string][]string `yaml:"query,omitempty" toml:"query,omitempty" json:"query,omitempty"`
+	Fragment string              `yaml:"fragment,omitempty" toml:"fragment,omitempty" json:"fragment,omitempty"`
+}
+
+// parseURL writes the parts of a url, with the path split into decoded segments and the query as a multimap
+func parseURL(c *cli.Command, s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	res := parsedURL{
+		Scheme:   u.Scheme,
+		Opaque:   u.Opaque,
+		Host:     u.Hostname(),
+		Port:     u.Port(),
+		Path:     u.Path,
+		Fragment: u.Fragment,
+	}
+	if len(query) > 0 {
+		res.Query = query
+	}
+	if u.User != nil {
+		res.User = u.User.Username()
+		if password, ok := u.User.Password(); ok {
+			res.Password = &password
+		}
+	}
+	for _, segment := range strings.Split(strings.TrimPrefix(u.EscapedPath(), "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		segment, err = url.PathUnescape(segment)
+		if err != nil {
+			return err
+		}
+		res.Segments = append(res.Segments, segment)
+	}
+
+	format := c.String("format")
+	j, err := utils.Marshaller(format)(res)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %s", format, err)
+	}
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(c.Writer, p.Format(format, string(j)))
+	return err
+}
+
 func decodeBinary(ctx context.Context, c *cli.Command) error {
 	in := c.Reader
 	out := c.Writer
@@ -79,6 +158,24 @@ func decodeBase64(ctx context.Context, c *cli.Command) error {
 	in := c.Reader
 	out := c.Writer
 
+	if !c.Bool("strict") {
+		b, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+		s := strings.TrimRight(strings.Join(strings.Fields(string(b)), ""), "=")
+		e := base64.RawStdEncoding
+		if c.Bool("url") || strings.ContainsAny(s, "-_") {
+			e = base64.RawURLEncoding
+		}
+		res, err := e.DecodeString(s)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(res)
+		return err
+	}
+
 	e := base64.StdEncoding
 	if c.Bool("url") {
 		e = base64.URLEncoding
@@ -93,6 +190,24 @@ func decodeBase32(ctx context.Context, c *cli.Command) error {
 	in := c.Reader
 	out := c.Writer
 
+	if !c.Bool("strict") {
+		b, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+		s := strings.TrimRight(strings.Join(strings.Fields(string(b)), ""), "=")
+		e := base32.StdEncoding
+		if c.Bool("hex") {
+			e = base32.HexEncoding
+		}
+		res, err := e.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(s))
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(res)
+		return err
+	}
+
 	e := base32.StdEncoding
 	if c.Bool("hex") {
 		e = base32.HexEncoding
@@ -106,32 +221,40 @@ func decodeHex(ctx context.Context, c *cli.Command) error {
 	in := c.Reader
 	out := c.Writer
 
-	d := hex.NewDecoder(in)
-	_, err := io.Copy(out, d)
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	res, err := hex.DecodeString(string(cleanHex(b)))
+	if err != nil {
+		return fmt.Errorf("failed to decode hex: %w", err)
+	}
+	_, err = out.Write(res)
 	return err
 }
 
-func decodeMIME(ctx context.Context, c *cli.Command) error {
-	in := c.Reader
-	out := c.Writer
+// charsetReader converts input in charset to utf-8, unknown charsets are passed through as is
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	charset = strings.ToLower(charset)
+	if m, ok := utils.CharsetEncodings[charset]; ok {
+		rr := transform.NewReader(input, m.NewDecoder())
+		return rr, nil
+	}
 
-	d := &mime.WordDecoder{}
+	charset = utils.CharsetAliases[charset]
+	if m, ok := utils.CharsetEncodings[charset]; ok {
+		rr := transform.NewReader(input, m.NewDecoder())
+		return rr, nil
+	}
 
-	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
-		charset = strings.ToLower(charset)
-		if m, ok := utils.CharsetEncodings[charset]; ok {
-			rr := transform.NewReader(input, m.NewDecoder())
-			return rr, nil
-		}
+	return input, nil
+}
 
-		charset = utils.CharsetAliases[charset]
-		if m, ok := utils.CharsetEncodings[charset]; ok {
-			rr := transform.NewReader(input, m.NewDecoder())
-			return rr, nil
-		}
+func decodeMIME(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
 
-		return input, nil
-	}
+	d := &mime.WordDecoder{CharsetReader: charsetReader}
 
 	header, err := io.ReadAll(in)
 	if err != nil {
@@ -182,14 +305,15 @@ func decodeJWT(ctx context.Context, c *cli.Command) error {
 		Signature: sigString,
 	}
 
-	f := prettyjson.NewFormatter()
-	f.Indent = 2
-	f.DisabledColor = false
-	tokenData, err := f.Marshal(token)
+	p, err := highlight.New(c)
 	if err != nil {
 		return err
 	}
-	_, err = out.Write(tokenData)
+	tokenData, err := json.MarshalIndent(token, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, p.JSON(string(tokenData)))
 
 	return err
 }
@@ -225,31 +349,423 @@ func decodeXID(ctx context.Context, c *cli.Command) error {
 		Counter: id.Counter(),
 	}
 
-	var marshaller func(any) ([]byte, error)
+	j, err := utils.Marshaller(c.String("format"))(uid)
+	if err != nil {
+		return fmt.Errorf("failed to marshal json: %s", err)
+	}
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, p.Format(c.String("format"), string(j)))
+
+	return err
 
-	switch c.String("format") {
-	case "json":
-		marshaller = json.Marshal
-	case "xml":
-		marshaller = xml.Marshal
-	case "yaml", "yml":
-		marshaller = yaml.Marshal
-	case "toml":
-		marshaller = toml.Marshal
-	case "text", "txt":
-		marshaller = func(z any) ([]byte, error) {
-			return []byte(fmt.Sprintf("%v", z)), nil
+}
+
+func decodeHTML(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, html.UnescapeString(string(b)))
+	return err
+}
+
+func base58Alphabet(c *cli.Command) (*utils.BaseX, error) {
+	b, ok := utils.Base58Alphabets[c.String("alphabet")]
+	if !ok {
+		return nil, fmt.Errorf("unknown base58 alphabet %s, expected bitcoin, flickr or ripple", c.String("alphabet"))
+	}
+	return b, nil
+}
+
+func decodeBase58(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := base58Alphabet(c)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	res, err := b.Decode(strings.TrimSpace(string(data)))
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(res)
+	return err
+}
+
+func decodeBase58Check(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := base58Alphabet(c)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	version, payload, err := utils.Base58CheckDecode(b, strings.TrimSpace(string(data)))
+	if err != nil {
+		return err
+	}
+
+	format := c.String("format")
+	if format == "raw" {
+		// std out only gets the payload, so that it can be piped, the version byte is reported on std err
+		_, err = fmt.Fprintf(c.Root().ErrWriter, "version: %d\n", version)
+		if err != nil {
+			return err
 		}
-	default:
-		marshaller = json.Marshal
+		_, err = out.Write(payload)
+		return err
 	}
 
-	j, err := marshaller(uid)
+	type base58check struct {
+		Version byte   `yaml:"version" toml:"version" json:"version" xml:"version"`
+		Payload string `yaml:"payload" toml:"payload" json:"payload" xml:"payload"`
+	}
+	j, err := utils.Marshaller(format)(base58check{Version: version, Payload: hex.EncodeToString(payload)})
 	if err != nil {
-		return fmt.Errorf("failed to marshal json: %s", err)
+		return fmt.Errorf("failed to marshal %s: %s", format, err)
+	}
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
 	}
-	_, err = out.Write(j)
+	_, err = io.WriteString(out, p.Format(format, string(j)))
+	return err
+}
 
+func decodeBase62(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	res, err := utils.Base62.Decode(strings.TrimSpace(string(data)))
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(res)
 	return err
+}
 
+// ascii85Delimited strips an optional <~ from the start of the input and ends it at ~>
+type ascii85Delimited struct {
+	r     *bufio.Reader
+	start bool
+	done  bool
+}
+
+func (a *ascii85Delimited) Read(p []byte) (int, error) {
+	if !a.start {
+		a.start = true
+		for {
+			b, err := a.r.Peek(1)
+			if err != nil || (b[0] != ' ' && b[0] != '\n' && b[0] != '\r' && b[0] != '\t') {
+				break
+			}
+			_, _ = a.r.ReadByte()
+		}
+		if b, err := a.r.Peek(2); err == nil && string(b) == "<~" {
+			_, _ = a.r.Discard(2)
+		}
+	}
+	if a.done {
+		return 0, io.EOF
+	}
+	n := 0
+	for n < len(p) {
+		c, err := a.r.ReadByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if c == '~' {
+			// ~ is not part of the alphabet, it can only start the end delimiter
+			a.done = true
+			break
+		}
+		p[n] = c
+		n++
+	}
+	if n == 0 && a.done {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func decodeAscii85(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	d := ascii85.NewDecoder(&ascii85Delimited{r: bufio.NewReader(in)})
+	_, err := io.Copy(out, d)
+	if err != nil {
+		return fmt.Errorf("failed to decode ascii85: %w", err)
+	}
+	return nil
+}
+
+func decodeBase85(enc *utils.Base85) cli.ActionFunc {
+	return func(ctx context.Context, c *cli.Command) error {
+		in := c.Reader
+		out := c.Writer
+
+		d := utils.NewBase85Decoder(enc, in)
+		_, err := io.Copy(out, d)
+		return err
+	}
+}
+
+func decodeBase36(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	res, err := utils.Base36.DecodeFold(strings.TrimSpace(string(data)))
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(res)
+	return err
+}
+
+func decodeBase45(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	// space is part of the alphabet, only line breaks are trimmed
+	res, err := utils.Base45Decode(strings.TrimRight(string(data), "\r\n"))
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(res)
+	return err
+}
+
+func decodeBech32(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	hrp, values, variant, err := utils.Bech32Decode(strings.TrimSpace(string(b)))
+	if err != nil {
+		return err
+	}
+
+	var witnessVersion *byte
+	if c.Bool("segwit") {
+		if len(values) == 0 {
+			return errors.New("segwit data is missing the witness version")
+		}
+		witnessVersion = &values[0]
+		values = values[1:]
+		if *witnessVersion > 16 {
+			return fmt.Errorf("witness version has to be 0-16, got %d", *witnessVersion)
+		}
+		if segwit := utils.SegwitVariant(*witnessVersion); variant != segwit {
+			return fmt.Errorf("witness version %d has to use %s, got %s", *witnessVersion, segwit, variant)
+		}
+	}
+	data, err := utils.ConvertBits(values, 5, 8, false)
+	if err != nil {
+		return err
+	}
+
+	format := c.String("format")
+	if format == "raw" {
+		_, err = out.Write(data)
+		return err
+	}
+
+	type bech32 struct {
+		HRP            string `yaml:"hrp" toml:"hrp" json:"hrp" xml:"hrp"`
+		Variant        string `yaml:"variant" toml:"variant" json:"variant" xml:"variant"`
+		WitnessVersion *byte  `yaml:"witness_version,omitempty" toml:"witness_version,omitempty" json:"witness_version,omitempty" xml:"witness_version,omitempty"`
+		Data           string `yaml:"data" toml:"data" json:"data" xml:"data"`
+	}
+	j, err := utils.Marshaller(format)(bech32{HRP: hrp, Variant: variant, WitnessVersion: witnessVersion, Data: hex.EncodeToString(data)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %s", format, err)
+	}
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, p.Format(format, string(j)))
+	return err
+}
+
+func decodeQuotedPrintable(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	_, err := io.Copy(out, quotedprintable.NewReader(in))
+	if err != nil {
+		return fmt.Errorf("failed to decode quoted-printable: %w", err)
+	}
+	return nil
+}
+
+type dataURIParam struct {
+	Name  string `yaml:"name" toml:"name" json:"name" xml:"name"`
+	Value string `yaml:"value" toml:"value" json:"value" xml:"value"`
+}
+
+type dataURI struct {
+	MediaType string         `yaml:"media_type" toml:"media_type" json:"media_type" xml:"media_type"`
+	Params    []dataURIParam `yaml:"params,omitempty" toml:"params,omitempty" json:"params,omitempty" xml:"param,omitempty"`
+	Charset   string         `yaml:"charset,omitempty" toml:"charset,omitempty" json:"charset,omitempty" xml:"charset,omitempty"`
+	Base64    bool           `yaml:"base64" toml:"base64" json:"base64" xml:"base64"`
+	Size      int            `yaml:"size" toml:"size" json:"size" xml:"size"`
+}
+
+// parseDataURI parses a RFC 2397 data uri, data:[<media type>][;<param>=<value>]*[;base64],<data>, into its meta
+// data and the payload, converted to utf-8 if a charset is given
+func parseDataURI(s string) (dataURI, []byte, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 5 || !strings.EqualFold(s[:5], "data:") {
+		return dataURI{}, nil, errors.New("data uris have to start with data:")
+	}
+	comma := strings.IndexByte(s, ',')
+	if comma < 0 {
+		return dataURI{}, nil, errors.New("missing the , between the media type and the data")
+	}
+
+	params := strings.Split(s[5:comma], ";")
+	res := dataURI{MediaType: strings.ToLower(strings.TrimSpace(params[0]))}
+	params = params[1:]
+	if n := len(params); n > 0 && strings.EqualFold(strings.TrimSpace(params[n-1]), "base64") {
+		res.Base64 = true
+		params = params[:n-1]
+	}
+	for _, param := range params {
+		k, v, ok := strings.Cut(param, "=")
+		if !ok {
+			return dataURI{}, nil, fmt.Errorf("invalid parameter %q, expected name=value", param)
+		}
+		v, err := url.PathUnescape(v)
+		if err != nil {
+			return dataURI{}, nil, fmt.Errorf("invalid parameter %q: %w", param, err)
+		}
+		k = strings.ToLower(strings.TrimSpace(k))
+		if k == "charset" {
+			res.Charset = v
+		}
+		res.Params = append(res.Params, dataURIParam{Name: k, Value: v})
+	}
+	if res.MediaType == "" {
+		// the default of RFC 2397
+		res.MediaType = "text/plain"
+		if res.Charset == "" {
+			res.Charset = "US-ASCII"
+		}
+	}
+
+	payload, err := url.PathUnescape(s[comma+1:])
+	if err != nil {
+		return dataURI{}, nil, fmt.Errorf("invalid data: %w", err)
+	}
+	data := []byte(payload)
+	if res.Base64 {
+		payload = strings.TrimRight(strings.Join(strings.Fields(payload), ""), "=")
+		e := base64.RawStdEncoding
+		if strings.ContainsAny(payload, "-_") {
+			e = base64.RawURLEncoding
+		}
+		data, err = e.DecodeString(payload)
+		if err != nil {
+			return dataURI{}, nil, fmt.Errorf("invalid base64 data: %w", err)
+		}
+	}
+
+	if res.Charset != "" {
+		r, err := charsetReader(res.Charset, bytes.NewReader(data))
+		if err != nil {
+			return dataURI{}, nil, err
+		}
+		data, err = io.ReadAll(r)
+		if err != nil {
+			return dataURI{}, nil, fmt.Errorf("failed to convert %s to utf-8: %w", res.Charset, err)
+		}
+	}
+	res.Size = len(data)
+	return res, data, nil
+}
+
+func decodeDataURI(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	uri, data, err := parseDataURI(string(b))
+	if err != nil {
+		return err
+	}
+
+	format := c.String("format")
+	if format == "raw" {
+		_, err = out.Write(data)
+		return err
+	}
+
+	j, err := utils.Marshaller(format)(uri)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %s", format, err)
+	}
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, p.Format(format, string(j)))
+	return err
+}
+
+func decodePunycode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	label := strings.TrimSpace(string(b))
+	if len(label) >= 4 && strings.EqualFold(label[:4], "xn--") {
+		label = label[4:]
+	}
+	s, err := idna.Punycode.ToUnicode("xn--" + label)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, s)
+	return err
 }
@@ -0,0 +1,189 @@
+package decoders
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// dumpLine is a parsed line of a hexdump. Lines of only an offset end a dump, and * lines repeat the line before
+type dumpLine struct {
+	offset string
+	data   []byte
+	repeat bool
+	words  bool // 4 digit groups of od -x and hexdump, which are little endian 16 bit words
+}
+
+var (
+	dumpOffset = regexp.MustCompile(`^([0-9a-fA-F]{4,}):?$`)
+	dumpSpaces = regexp.MustCompile(`\s{2,}`)
+)
+
+// parseDumpLine parses a line of xxd, hexdump -C, od -x or a wireshark dump. The ascii gutter is told apart from
+// the bytes by its length, as it has one character per byte
+func parseDumpLine(line string, n int) (dumpLine, error) {
+	line = strings.TrimRight(line, "\r")
+	if strings.TrimSpace(line) == "*" {
+		return dumpLine{repeat: true}, nil
+	}
+	fields := strings.Fields(line)
+	m := dumpOffset.FindStringSubmatch(fields[0])
+	if m == nil {
+		return dumpLine{}, fmt.Errorf("line %d does not start with an offset: %q", n, prefix(line))
+	}
+	res := dumpLine{offset: m[1]}
+	xxd := strings.HasSuffix(fields[0], ":")
+
+	rest := strings.TrimLeft(line[strings.Index(line, fields[0])+len(fields[0]):], " \t")
+	if i := strings.Index(rest, "|"); i >= 0 {
+		rest = rest[:i]
+	}
+	for rest != "" {
+		if len(res.data) > 0 && len(strings.TrimRight(rest, " ")) <= len(res.data) {
+			// the gutter
+			break
+		}
+		segment := rest
+		if loc := dumpSpaces.FindStringIndex(rest); loc != nil {
+			segment, rest = rest[:loc[0]], rest[loc[1]:]
+		} else {
+			rest = ""
+		}
+
+		for _, group := range strings.FieldsFunc(segment, isDumpSeparator) {
+			b, err := hex.DecodeString(group)
+			if err != nil {
+				if len(res.data) > 0 {
+					// a gutter that happened to be longer than the data, eg. after a trimmed line
+					return res, nil
+				}
+				return dumpLine{}, fmt.Errorf("line %d has invalid hex %q", n, group)
+			}
+			if len(group) == 4 && !xxd && len(res.offset) == 7 {
+				res.words = true
+				b[0], b[1] = b[1], b[0]
+			}
+			res.data = append(res.data, b...)
+		}
+	}
+	return res, nil
+}
+
+// isDumpSeparator reports if r separates groups of bytes, white space or one of the separators encode hexdump takes
+func isDumpSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == ':' || r == '-' || r == ','
+}
+
+// dumpOffsetBase tells if offsets are hex or octal, od uses octal, by which base every offset is the byte count of
+// the line before it. When both fit, eg. when a * leaves only the short last line to compare, word dumps with 7 digit
+// offsets are taken to be od's
+func dumpOffsetBase(lines []dumpLine) int {
+	fits := map[int]bool{16: true, 8: true}
+	words := false
+	for i := 0; i+1 < len(lines); i++ {
+		words = words || lines[i].words
+		if lines[i].repeat || lines[i+1].repeat || len(lines[i].data) == 0 {
+			continue
+		}
+		size := int64(len(lines[i].data))
+		// the final offset cuts the padding byte of an odd length word dump
+		padded := lines[i].words && len(lines[i+1].data) == 0
+		for base := range fits {
+			a, err1 := strconv.ParseInt(lines[i].offset, base, 64)
+			b, err2 := strconv.ParseInt(lines[i+1].offset, base, 64)
+			if err1 != nil || err2 != nil || (b-a != size && !(padded && b-a == size-1)) {
+				delete(fits, base)
+			}
+		}
+	}
+	if fits[8] && (!fits[16] || words) {
+		return 8
+	}
+	return 16
+}
+
+func parseHexdump(src string) ([]byte, error) {
+	var lines []dumpLine
+	for n, line := range strings.Split(src, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		l, err := parseDumpLine(line, n+1)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, l)
+	}
+	base := dumpOffsetBase(lines)
+
+	var res []byte
+	var prev []byte
+	repeat := false
+	for _, l := range lines {
+		if l.repeat {
+			repeat = true
+			continue
+		}
+		offset, err := strconv.ParseInt(l.offset, base, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offset %s", l.offset)
+		}
+		if repeat {
+			for int64(len(res)) < offset && len(prev) > 0 {
+				res = append(res, prev...)
+			}
+			repeat = false
+		}
+		if int64(len(res)) != offset && len(l.data) > 0 {
+			return nil, fmt.Errorf("offset %s does not follow the %d bytes before it", l.offset, len(res))
+		}
+		if len(l.data) == 0 {
+			// the final offset is the length, it cuts the padding of odd length word dumps
+			if int64(len(res)) > offset {
+				res = res[:offset]
+			}
+			break
+		}
+		res = append(res, l.data...)
+		prev = l.data
+	}
+	return res, nil
+}
+
+func decodeHexdump(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	res, err := parseHexdump(string(b))
+	if err != nil {
+		return fmt.Errorf("failed to parse hexdump: %w", err)
+	}
+	_, err = out.Write(res)
+	return err
+}
+
+// cleanHex removes white space, colons, commas and 0x prefixes from hex strings like 0x48, 0x65 or 48:65
+func cleanHex(b []byte) []byte {
+	res := make([]byte, 0, len(b))
+	for i := 0; i < len(b); i++ {
+		c := b[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ':' || c == ',':
+		case c == '0' && i+1 < len(b) && (b[i+1] == 'x' || b[i+1] == 'X') && (i == 0 || strings.IndexByte(" \t\n\r:,", b[i-1]) >= 0):
+			i++
+		default:
+			res = append(res, c)
+		}
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+package decoders
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestDecodeHexdump(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "xxd",
+			input:    "00000000: 4865 6c6c 6f20 576f 726c 640a 6361 6665  Hello World.cafe\n00000010: 6361 6665                                cafe\n",
+			expected: "Hello World\ncafecafe",
+		},
+		{
+			name:     "hexdump -C with repeated lines",
+			input:    "00000000  00 00 00 00 00 00 00 00  00 00 00 00 00 00 00 00  |................|\n*\n00000030  41 0a                                             |A.|\n00000032\n",
+			expected: strings.Repeat("\x00", 48) + "A\n",
+		},
+		{
+			name:     "wireshark",
+			input:    "0000   48 65 6c 6c 6f 20 57 6f 72 6c 64 0a 48 65 6c 6c   Hello World.Hell\n0010   6f                                                o",
+			expected: "Hello World\nHello",
+		},
+		{
+			name:     "od -x with octal offsets and odd length",
+			input:    "0000000 6548 6c6c 206f 6f57 6c72 0a64 6e61 2064\n0000020 6f6d 6572 0a21\n0000025\n",
+			expected: "Hello World\nand more!",
+		},
+		{
+			name:     "od -x with a repeated line",
+			input:    "0000000 6161 6161 6161 6161 6161 6161 6161 6161\n*\n0000060 6161 6161 6261\n0000066\n",
+			expected: strings.Repeat("a", 53) + "b",
+		},
+		{
+			name:     "gutter of spaces",
+			input:    "00000000: 2020 2020                                    \n",
+			expected: "    ",
+		},
+		{
+			name:     "encode hexdump with : separator",
+			input:    "00000000: 6769:7468:7562:2e63:6f6d:2f63:7268:6f6c  github.com/crhol\n00000010: 6d2f:696f:703a:2000:01ff                 m/iop: ...",
+			expected: "github.com/crholm/iop: \x00\x01\xff",
+		},
+		{
+			name:     "encode hexdump with - separator",
+			input:    "00000000: 61-2d-62-3a-63                                   a-b:c",
+			expected: "a-b:c",
+		},
+		{
+			name:     "encode hexdump -C with , separator",
+			input:    "00000000  61,2c,62,2c,63,2c,2c,2c, 2d,2d,3a,3a,41,42,43,44  |a,b,c,,,--::ABCD|\n00000010  45                                                |E|\n00000011",
+			expected: "a,b,c,,,--::ABCDE",
+		},
+		{
+			name:    "no offset",
+			input:   "hello world",
+			wantErr: true,
+		},
+		{
+			name:    "gap in offsets",
+			input:   "00000000: 4142  AB\n00000010: 4344  CD",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+			}
+			err := decodeHexdump(context.Background(), cmd)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeHexdump() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("decodeHexdump() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,509 @@
+package decoders
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// escapeRules are the backslash escapes a language understands in its string literals
+type escapeRules struct {
+	octal        bool // \0 to \377
+	hex          bool // \xHH
+	unicode      bool // \uXXXX, surrogate pairs are joined
+	longUnicode  bool // \UXXXXXXXX
+	braceUnicode bool // \u{X...}
+	bytes        bool // \x and octal escapes are bytes, rather than code points
+	shell        bool // \e and \E
+	keepUnknown  bool // unknown escapes are kept with their backslash, rather than being an error
+	dropUnknown  bool // unknown escapes are the character itself
+}
+
+var (
+	cRules      = escapeRules{octal: true, hex: true, unicode: true, longUnicode: true, bytes: true}
+	javaRules   = escapeRules{octal: true, unicode: true}
+	jsRules     = escapeRules{hex: true, unicode: true, braceUnicode: true, dropUnknown: true}
+	pythonRules = escapeRules{octal: true, hex: true, unicode: true, longUnicode: true, keepUnknown: true}
+	shellRules  = escapeRules{octal: true, hex: true, unicode: true, longUnicode: true, bytes: true, shell: true, keepUnknown: true}
+)
+
+var simpleEscapes = map[byte]byte{
+	'n': '\n', 't': '\t', 'r': '\r', 'a': '\a', 'b': '\b', 'f': '\f', 'v': '\v',
+	'\\': '\\', '\'': '\'', '"': '"', '`': '`', '?': '?', '$': '$',
+}
+
+// unescape resolves the backslash escapes of the body of a literal
+func (e escapeRules) unescape(s string) ([]byte, error) {
+	var res []byte
+	var pending []uint16 // utf-16 code units waiting for their pair
+
+	flush := func() {
+		if len(pending) > 0 {
+			res = utf8.AppendRune(res, utf16.Decode(pending)[0])
+			pending = nil
+		}
+	}
+	hexValue := func(i, n int) (uint64, error) {
+		if i+n > len(s) {
+			return 0, fmt.Errorf("short escape \\%s at offset %d", s[i-1:], i-2)
+		}
+		v, err := strconv.ParseUint(s[i:i+n], 16, 32)
+		if err != nil {
+			return 0, fmt.Errorf("invalid escape \\%s at offset %d", s[i-1:i+n], i-2)
+		}
+		return v, nil
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			flush()
+			res = append(res, s[i])
+			continue
+		}
+		i++
+		c := s[i]
+		switch {
+		case c == '\n':
+			// line continuation
+			flush()
+		case simpleEscapes[c] != 0:
+			flush()
+			res = append(res, simpleEscapes[c])
+		case e.shell && (c == 'e' || c == 'E'):
+			flush()
+			res = append(res, 0x1b)
+		case e.octal && c >= '0' && c <= '7':
+			flush()
+			n := i
+			for n < len(s) && n < i+3 && s[n] >= '0' && s[n] <= '7' {
+				n++
+			}
+			v, _ := strconv.ParseUint(s[i:n], 8, 32)
+			if v > 0xff {
+				return nil, fmt.Errorf("octal escape \\%s at offset %d is out of range", s[i:n], i-1)
+			}
+			res = appendValue(res, rune(v), e.bytes)
+			i = n - 1
+		case !e.octal && c == '0' && (i+1 == len(s) || s[i+1] < '0' || s[i+1] > '9'):
+			flush()
+			res = append(res, 0)
+		case e.hex && c == 'x':
+			flush()
+			v, err := hexValue(i+1, 2)
+			if err != nil {
+				return nil, err
+			}
+			res = appendValue(res, rune(v), e.bytes)
+			i += 2
+		case e.braceUnicode && c == 'u' && i+1 < len(s) && s[i+1] == '{':
+			flush()
+			end := strings.IndexByte(s[i:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated escape \\u{ at offset %d", i-1)
+			}
+			v, err := hexValue(i+2, end-2)
+			if err != nil || v > utf8.MaxRune {
+				return nil, fmt.Errorf("invalid escape \\%s at offset %d", s[i:i+end+1], i-1)
+			}
+			res = utf8.AppendRune(res, rune(v))
+			i += end
+		case e.unicode && c == 'u':
+			v, err := hexValue(i+1, 4)
+			if err != nil {
+				return nil, err
+			}
+			if utf16.IsSurrogate(rune(v)) {
+				pending = append(pending, uint16(v))
+				if len(pending) == 2 {
+					flush()
+				}
+			} else {
+				flush()
+				res = utf8.AppendRune(res, rune(v))
+			}
+			i += 4
+		case e.longUnicode && c == 'U':
+			flush()
+			v, err := hexValue(i+1, 8)
+			if err != nil {
+				return nil, err
+			}
+			if v > utf8.MaxRune {
+				return nil, fmt.Errorf("escape \\%s at offset %d is not a code point", s[i:i+9], i-1)
+			}
+			res = utf8.AppendRune(res, rune(v))
+			i += 8
+		case e.keepUnknown:
+			flush()
+			res = append(res, '\\', c)
+		case e.dropUnknown:
+			flush()
+			res = append(res, c)
+		default:
+			return nil, fmt.Errorf("unknown escape \\%c at offset %d", c, i-1)
+		}
+	}
+	flush()
+	return res, nil
+}
+
+func appendValue(b []byte, v rune, bytes bool) []byte {
+	if bytes {
+		return append(b, byte(v))
+	}
+	return utf8.AppendRune(b, v)
+}
+
+// quotedEnd returns the index just after the closing quote of a literal starting at s[start], where a backslash
+// escapes the next character when escapes is set, and a doubled quote is a quote when doubled is set
+func quotedEnd(s string, start int, quote string, escapes bool, doubled bool) (int, error) {
+	for i := start + len(quote); i < len(s); i++ {
+		switch {
+		case escapes && s[i] == '\\':
+			i++
+		case strings.HasPrefix(s[i:], quote):
+			if doubled && strings.HasPrefix(s[i+len(quote):], quote) {
+				i += len(quote)*2 - 1
+				continue
+			}
+			return i + len(quote), nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated literal starting at offset %d", start)
+}
+
+// literalParser reads a single literal at the start of s, returning its value and length
+type literalParser func(s string) ([]byte, int, error)
+
+func goLiteral(s string) ([]byte, int, error) {
+	var end int
+	var err error
+	switch s[0] {
+	case '"':
+		end, err = quotedEnd(s, 0, `"`, true, false)
+	case '`':
+		end, err = quotedEnd(s, 0, "`", false, false)
+	case '\'':
+		end, err = quotedEnd(s, 0, "'", true, false)
+	default:
+		return nil, 0, fmt.Errorf("expected a go string literal, found %q", prefix(s))
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	v, err := strconv.Unquote(s[:end])
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid go literal %s: %w", prefix(s[:end]), err)
+	}
+	return []byte(v), end, nil
+}
+
+func quotedLiteral(name string, quotes string, rules escapeRules) literalParser {
+	return func(s string) ([]byte, int, error) {
+		if s == "" || !strings.ContainsRune(quotes, rune(s[0])) {
+			return nil, 0, fmt.Errorf("expected a %s string literal, found %q", name, prefix(s))
+		}
+		end, err := quotedEnd(s, 0, s[:1], true, false)
+		if err != nil {
+			return nil, 0, err
+		}
+		body := s[1 : end-1]
+		if s[0] == '`' && strings.Contains(body, "${") {
+			return nil, 0, fmt.Errorf("template literals with substitutions can not be decoded")
+		}
+		v, err := rules.unescape(body)
+		return v, end, err
+	}
+}
+
+func cLiteral(s string) ([]byte, int, error) {
+	// wide and utf-8 prefixes, L"", u8"", u"" and U""
+	p := 0
+	for p < 2 && p < len(s) && strings.IndexByte("LuU8", s[p]) >= 0 {
+		p++
+	}
+	v, end, err := quotedLiteral("c", `"`, cRules)(s[p:])
+	return v, end + p, err
+}
+
+func javaLiteral(s string) ([]byte, int, error) {
+	if !strings.HasPrefix(s, `"""`) {
+		return quotedLiteral("java", `"`, javaRules)(s)
+	}
+	end, err := quotedEnd(s, 0, `"""`, true, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	// text blocks start on the line after the quotes and lose their common indentation
+	body := s[3 : end-3]
+	nl := strings.IndexByte(body, '\n')
+	if nl < 0 {
+		return nil, 0, fmt.Errorf("a java text block has to start with a new line")
+	}
+	lines := strings.Split(body[nl+1:], "\n")
+	indent := -1
+	for n, l := range lines {
+		if strings.TrimSpace(l) == "" && n != len(lines)-1 {
+			continue
+		}
+		w := len(l) - len(strings.TrimLeft(l, " \t"))
+		if indent < 0 || w < indent {
+			indent = w
+		}
+	}
+	for n, l := range lines {
+		if len(l) >= indent {
+			lines[n] = strings.TrimRight(l[indent:], " \t")
+		} else {
+			lines[n] = ""
+		}
+	}
+	v, err := javaRules.unescape(strings.Join(lines, "\n"))
+	return v, end, err
+}
+
+func pythonLiteral(s string) ([]byte, int, error) {
+	p := 0
+	raw, isBytes := false, false
+	for p < 2 && p < len(s) && strings.IndexByte("rRbBuU", s[p]) >= 0 {
+		raw = raw || s[p] == 'r' || s[p] == 'R'
+		isBytes = isBytes || s[p] == 'b' || s[p] == 'B'
+		p++
+	}
+	if p == len(s) || (s[p] != '\'' && s[p] != '"') {
+		return nil, 0, fmt.Errorf("expected a python string literal, found %q", prefix(s))
+	}
+	quote := s[p : p+1]
+	if strings.HasPrefix(s[p:], quote+quote+quote) {
+		quote = quote + quote + quote
+	}
+	end, err := quotedEnd(s, p, quote, true, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	body := s[p+len(quote) : end-len(quote)]
+	if raw {
+		return []byte(body), end, nil
+	}
+	rules := pythonRules
+	rules.bytes = isBytes
+	rules.unicode = !isBytes
+	rules.longUnicode = !isBytes
+	v, err := rules.unescape(body)
+	return v, end, err
+}
+
+func sqlLiteral(s string) ([]byte, int, error) {
+	switch {
+	case len(s) > 1 && (s[0] == 'X' || s[0] == 'x') && s[1] == '\'':
+		end, err := quotedEnd(s, 1, "'", false, false)
+		if err != nil {
+			return nil, 0, err
+		}
+		v, err := hex.DecodeString(s[2 : end-1])
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid sql hex literal: %w", err)
+		}
+		return v, end, nil
+	case len(s) > 1 && (s[0] == 'E' || s[0] == 'e') && s[1] == '\'':
+		end, err := quotedEnd(s, 1, "'", true, true)
+		if err != nil {
+			return nil, 0, err
+		}
+		v, err := cRules.unescape(strings.ReplaceAll(s[2:end-1], "''", "'"))
+		return v, end, err
+	case len(s) > 1 && (s[0] == 'N' || s[0] == 'n') && s[1] == '\'':
+		v, end, err := sqlLiteral(s[1:])
+		return v, end + 1, err
+	case s[0] == '\'':
+		end, err := quotedEnd(s, 0, "'", false, true)
+		if err != nil {
+			return nil, 0, err
+		}
+		return []byte(strings.ReplaceAll(s[1:end-1], "''", "'")), end, nil
+	}
+	return nil, 0, fmt.Errorf("expected a sql string literal, found %q", prefix(s))
+}
+
+// shellWord reads a shell word made of quoted and unquoted parts, up to the first unquoted white space
+func shellWord(s string) ([]byte, int, error) {
+	var res []byte
+	i := 0
+	for i < len(s) {
+		switch {
+		case s[i] == ' ' || s[i] == '\t' || s[i] == '\n':
+			return res, i, nil
+		case s[i] == '\'':
+			end, err := quotedEnd(s, i, "'", false, false)
+			if err != nil {
+				return nil, 0, err
+			}
+			res = append(res, s[i+1:end-1]...)
+			i = end
+		case strings.HasPrefix(s[i:], "$'"):
+			end, err := quotedEnd(s, i+1, "'", true, false)
+			if err != nil {
+				return nil, 0, err
+			}
+			v, err := shellRules.unescape(s[i+2 : end-1])
+			if err != nil {
+				return nil, 0, err
+			}
+			res = append(res, v...)
+			i = end
+		case s[i] == '"':
+			end, err := quotedEnd(s, i, `"`, true, false)
+			if err != nil {
+				return nil, 0, err
+			}
+			// only \\, \", \$, \` and line continuations are escapes inside double quotes
+			body := s[i+1 : end-1]
+			for n := 0; n < len(body); n++ {
+				if body[n] == '\\' && n+1 < len(body) && strings.IndexByte("\\\"$`\n", body[n+1]) >= 0 {
+					n++
+					if body[n] == '\n' {
+						continue
+					}
+				} else if body[n] == '$' || body[n] == '`' {
+					return nil, 0, fmt.Errorf("expansions can not be decoded, found %q", prefix(body[n:]))
+				}
+				res = append(res, body[n])
+			}
+			i = end
+		case s[i] == '\\' && i+1 < len(s):
+			if s[i+1] != '\n' {
+				res = append(res, s[i+1])
+			}
+			i += 2
+		case s[i] == '$' || s[i] == '`':
+			return nil, 0, fmt.Errorf("expansions can not be decoded, found %q", prefix(s[i:]))
+		default:
+			res = append(res, s[i])
+			i++
+		}
+	}
+	return res, i, nil
+}
+
+// byteArray parses the elements of a byte array literal, like []byte{0x01, 2} or new byte[] {(byte) 0xff}
+func byteArray(s string) ([]byte, error) {
+	open := strings.IndexAny(s, "{")
+	closing := strings.LastIndex(s, "}")
+	if open < 0 {
+		open = strings.IndexAny(s, "[")
+		closing = strings.LastIndex(s, "]")
+	}
+	if closing < open {
+		return nil, fmt.Errorf("unterminated byte array")
+	}
+
+	var res []byte
+	for n, e := range strings.Split(s[open+1:closing], ",") {
+		e = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(e), "(byte)"))
+		if e == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(e, 0, 16)
+		if err != nil && len(e) == 3 && e[0] == '\'' && e[2] == '\'' {
+			// character literals
+			v, err = int64(e[1]), nil
+		}
+		if err != nil || v < -128 || v > 255 {
+			return nil, fmt.Errorf("element %d of the byte array, %s, is not a byte", n, e)
+		}
+		res = append(res, byte(v))
+	}
+	return res, nil
+}
+
+// isByteArray reports if the literal is an array, that is if it has a bracket before any quote
+func isByteArray(s string) bool {
+	bracket := strings.IndexAny(s, "{[")
+	quote := strings.IndexAny(s, "'\"`")
+	return bracket >= 0 && (quote < 0 || bracket < quote)
+}
+
+func prefix(s string) string {
+	if len(s) > 16 {
+		return s[:16] + "..."
+	}
+	return s
+}
+
+// parseLiterals reads one or more literals, joined by white space, + or ||, which concatenates them
+func parseLiterals(s string, parse literalParser) ([]byte, error) {
+	var res []byte
+	for i := 0; ; {
+		for i < len(s) && strings.IndexByte(" \t\r\n+|", s[i]) >= 0 {
+			i++
+		}
+		if i == len(s) {
+			return res, nil
+		}
+		v, n, err := parse(s[i:])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v...)
+		i += n
+	}
+}
+
+func decodeLiteral(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	s := strings.TrimSpace(string(b))
+	s = strings.TrimSuffix(s, ";")
+	if s == "" {
+		return fmt.Errorf("no literal to decode")
+	}
+
+	lang := c.String("lang")
+	var res []byte
+	switch {
+	case lang == "json":
+		var v string
+		err = json.Unmarshal([]byte(s), &v)
+		res = []byte(v)
+	case lang == "shell":
+		var n int
+		res, n, err = shellWord(s)
+		if err == nil && n < len(s) {
+			err = fmt.Errorf("expected a single shell word, found %q after it", prefix(strings.TrimSpace(s[n:])))
+		}
+	case lang != "sql" && lang != "python" && isByteArray(s):
+		res, err = byteArray(s)
+	case lang == "go":
+		res, err = parseLiterals(s, goLiteral)
+	case lang == "c":
+		res, err = parseLiterals(s, cLiteral)
+	case lang == "java":
+		res, err = parseLiterals(s, javaLiteral)
+	case lang == "js":
+		res, err = parseLiterals(s, quotedLiteral("js", "'\"`", jsRules))
+	case lang == "python":
+		res, err = parseLiterals(s, pythonLiteral)
+	case lang == "sql":
+		res, err = parseLiterals(s, sqlLiteral)
+	default:
+		return fmt.Errorf("unknown language %s, expected go, c, python, js, java, json, shell or sql", lang)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to decode %s literal: %w", lang, err)
+	}
+
+	_, err = out.Write(res)
+	return err
+}
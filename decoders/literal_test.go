@@ -0,0 +1,73 @@
+package decoders
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestDecodeLiteral(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		input    string
+		expected string
+		wantErr  string
+	}{
+		{name: "go", lang: "go", input: `"say \"hi\"\n\x01\u00e9"`, expected: "say \"hi\"\n\x01é"},
+		{name: "go raw and concatenation", lang: "go", input: "`a\\b` +\n\t\"c\"", expected: "a\\bc"},
+		{name: "go bytes", lang: "go", input: "[]byte{\n\t0x00, 0xff, 10, 'a',\n}", expected: "\x00\xff\na"},
+		{name: "go invalid", lang: "go", input: `"\q"`, wantErr: "invalid go literal"},
+		{name: "c", lang: "c", input: `"a\tb\0331" "\xff" L"x"`, expected: "a\tb\x1b1\xffx"},
+		{name: "c xxd -i", lang: "c", input: "unsigned char data[] = {\n  0x00, 0xff\n};\nunsigned int data_len = 2;", expected: "\x00\xff"},
+		{name: "c unknown escape", lang: "c", input: `"\q"`, wantErr: "unknown escape \\q at offset 0"},
+		{name: "python", lang: "python", input: `'it\'s \xe9 \u00e9 \U0001f600 \d'`, expected: "it's é é 😀 \\d"},
+		{name: "python bytes", lang: "python", input: `b'\xff\x00'`, expected: "\xff\x00"},
+		{name: "python raw", lang: "python", input: `r"C:\dir"`, expected: `C:\dir`},
+		{name: "python triple quotes", lang: "python", input: `"""a "quoted"
+line"""`, expected: "a \"quoted\"\nline"},
+		{name: "js", lang: "js", input: `'\ud83d\ude00' + "\u{1F600}\x41\0"`, expected: "😀😀A\x00"},
+		{name: "js template", lang: "js", input: "`a ${b}`", wantErr: "substitutions"},
+		{name: "js array", lang: "js", input: "new Uint8Array([0x61, 0x62])", expected: "ab"},
+		{name: "java", lang: "java", input: `"\"\001\u00e9" + "x"`, expected: "\"\x01éx"},
+		{name: "java text block", lang: "java", input: "\"\"\"\n    a\n      b\n    \"\"\"", expected: "a\n  b\n"},
+		{name: "java bytes", lang: "java", input: "new byte[] {0x7f, (byte) 0x80, -1}", expected: "\x7f\x80\xff"},
+		{name: "json", lang: "json", input: `"a\u0001\ud83d\ude00"`, expected: "a\x01😀"},
+		{name: "shell", lang: "shell", input: `'it'\''s '\$x$'\e[0m'`, expected: "it's $x\x1b[0m"},
+		{name: "shell expansion", lang: "shell", input: `"$HOME"`, wantErr: "expansions can not be decoded"},
+		{name: "shell words", lang: "shell", input: `a b`, wantErr: "expected a single shell word"},
+		{name: "sql", lang: "sql", input: `'it''s' || E'\n'`, expected: "it's\n"},
+		{name: "sql hex", lang: "sql", input: `X'00FF'`, expected: "\x00\xff"},
+		{name: "unterminated", lang: "c", input: `"abc`, wantErr: "unterminated literal"},
+		{name: "unknown language", lang: "cobol", input: `"x"`, wantErr: "unknown language cobol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "lang", Value: "go"},
+				},
+				Action: decodeLiteral,
+			}
+			err := cmd.Run(context.Background(), []string{"", "--lang", tt.lang})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("decodeLiteral() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeLiteral() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("decodeLiteral() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
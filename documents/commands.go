@@ -0,0 +1,59 @@
+package documents
+
+import (
+	"github.com/urfave/cli/v3"
+)
+
+var DiffCommand = &cli.Command{
+	Name:      "diff",
+	Usage:     "semantic diff of two json, yaml or toml documents, exits with 1 if they differ and 2 on errors, as diff does",
+	ArgsUsage: "<a> <b> (use - to read one of them from std in)",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "input-format",
+			Aliases: []string{"i"},
+			Usage:   "format of the inputs, [json | yaml | toml]. Guessed from the file extension by default",
+		},
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Value:   "human",
+			Usage:   "output format, [human | json-patch | unified]",
+		},
+		&cli.StringFlag{
+			Name:    "array-key",
+			Aliases: []string{"k"},
+			Usage:   "match array elements by this object field instead of by index",
+		},
+	},
+	Action: diff,
+}
+
+var PatchCommand = &cli.Command{
+	Name:      "patch",
+	Usage:     "applies a json patch (RFC 6902) or a merge patch (RFC 7386) to a json, yaml or toml document",
+	ArgsUsage: "[document] (default std in)",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "json-patch",
+			Aliases: []string{"j"},
+			Usage:   "file containing a list of json patch operations",
+		},
+		&cli.StringFlag{
+			Name:    "merge",
+			Aliases: []string{"m"},
+			Usage:   "file containing a document to merge into the input",
+		},
+		&cli.StringFlag{
+			Name:    "input-format",
+			Aliases: []string{"i"},
+			Usage:   "format of the input document, [json | yaml | toml]. Guessed from the file extension or content by default",
+		},
+		&cli.StringFlag{
+			Name:    "output-format",
+			Aliases: []string{"o"},
+			Usage:   "format of the output, [json | yaml | toml]. Same as the input by default",
+		},
+	},
+	Action: patch,
+}
@@ -0,0 +1,420 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/crholm/iop/conversions"
+	"github.com/urfave/cli/v3"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// change is a single difference between two documents, expressed as a json patch operation
+type change struct {
+	Op      string   // add, remove or replace
+	Path    []string // unescaped json pointer tokens
+	Display string   // human readable path, eg. .items[id=3].name
+	From    any
+	To      any
+}
+
+type differ struct {
+	arrayKey string
+	changes  []change
+}
+
+func diff(ctx context.Context, c *cli.Command) error {
+	differs, err := diffDocuments(c)
+	if err != nil {
+		// as diff(1), trouble exits with 2 and differences with 1, so that scripts can tell them apart
+		return cli.Exit(err, 2)
+	}
+	if differs {
+		return cli.Exit("", 1)
+	}
+	return nil
+}
+
+// diffDocuments writes the differences of the two documents given as arguments and reports if there were any
+func diffDocuments(c *cli.Command) (bool, error) {
+	out := c.Writer
+
+	if c.Args().Len() != 2 {
+		return false, errors.New("expected exactly two documents to compare")
+	}
+
+	a, _, err := readDocument(c, c.Args().Get(0), c.String("input-format"))
+	if err != nil {
+		return false, err
+	}
+	b, _, err := readDocument(c, c.Args().Get(1), c.String("input-format"))
+	if err != nil {
+		return false, err
+	}
+
+	d := &differ{arrayKey: c.String("array-key")}
+	d.compare(nil, "", a, b)
+
+	switch c.String("output") {
+	case "human", "":
+		err = writeHuman(out, d.changes)
+	case "json-patch", "patch":
+		err = writePatch(out, d.changes)
+	case "unified", "diff":
+		err = writeUnified(out, c.Args().Get(0), c.Args().Get(1), a, b)
+	default:
+		return false, fmt.Errorf("unknown output format: %s", c.String("output"))
+	}
+	return len(d.changes) > 0, err
+}
+
+// readDocument decodes the file at path, or std in if path is -. The format is guessed from the file extension or
+// content unless given
+func readDocument(c *cli.Command, path string, format string) (any, string, error) {
+	var in io.Reader = c.Reader
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, "", err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if format == "" {
+		format = conversions.FormatOf(path)
+	}
+	if format == "" {
+		format = conversions.Sniff(b)
+	}
+
+	v, err := conversions.Decode(format, bytes.NewReader(b))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode %s: %s", path, err)
+	}
+	return v, format, nil
+}
+
+func (d *differ) compare(path []string, display string, a, b any) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		var keys []string
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			p := appendPath(path, k)
+			disp := display + displayKey(k)
+			aa, inA := av[k]
+			bb, inB := bv[k]
+			switch {
+			case !inB:
+				d.changes = append(d.changes, change{Op: "remove", Path: p, Display: disp, From: aa})
+			case !inA:
+				d.changes = append(d.changes, change{Op: "add", Path: p, Display: disp, To: bb})
+			default:
+				d.compare(p, disp, aa, bb)
+			}
+		}
+		return
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+		if d.arrayKey != "" && (d.keyed(av) || d.keyed(bv)) {
+			d.compareByKey(path, display, av, bv)
+			return
+		}
+		d.compareByIndex(path, display, av, bv)
+		return
+	}
+
+	if !equal(a, b) {
+		d.changes = append(d.changes, change{Op: "replace", Path: path, Display: rootDisplay(display), From: a, To: b})
+	}
+}
+
+func (d *differ) compareByIndex(path []string, display string, a, b []any) {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		d.compare(appendPath(path, strconv.Itoa(i)), fmt.Sprintf("%s[%d]", display, i), a[i], b[i])
+	}
+	// removing from the end keeps the indexes of the remaining elements valid when applied as a patch
+	for i := len(a) - 1; i >= len(b); i-- {
+		d.changes = append(d.changes, change{Op: "remove", Path: appendPath(path, strconv.Itoa(i)), Display: fmt.Sprintf("%s[%d]", display, i), From: a[i]})
+	}
+	for i := len(a); i < len(b); i++ {
+		d.changes = append(d.changes, change{Op: "add", Path: appendPath(path, "-"), Display: fmt.Sprintf("%s[%d]", display, i), To: b[i]})
+	}
+}
+
+// keyed reports if any element of the array is an object with the array key
+func (d *differ) keyed(items []any) bool {
+	for _, v := range items {
+		if m, ok := v.(map[string]any); ok {
+			if _, ok := m[d.arrayKey]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (d *differ) compareByKey(path []string, display string, a, b []any) {
+	keyOf := func(v any) (string, bool) {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		k, ok := m[d.arrayKey]
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("%v", k), true
+	}
+
+	inB := map[string]int{}
+	for j, v := range b {
+		if k, ok := keyOf(v); ok {
+			if _, dup := inB[k]; !dup {
+				inB[k] = j
+			}
+		}
+	}
+
+	matched := map[int]bool{}
+	var removed []int
+	for i, v := range a {
+		k, ok := keyOf(v)
+		j, found := inB[k]
+		if !ok || !found || matched[j] {
+			removed = append(removed, i)
+			continue
+		}
+		matched[j] = true
+		d.compare(appendPath(path, strconv.Itoa(i)), fmt.Sprintf("%s[%s=%s]", display, d.arrayKey, k), v, b[j])
+	}
+
+	for n := len(removed) - 1; n >= 0; n-- {
+		i := removed[n]
+		disp := fmt.Sprintf("%s[%d]", display, i)
+		if k, ok := keyOf(a[i]); ok {
+			disp = fmt.Sprintf("%s[%s=%s]", display, d.arrayKey, k)
+		}
+		d.changes = append(d.changes, change{Op: "remove", Path: appendPath(path, strconv.Itoa(i)), Display: disp, From: a[i]})
+	}
+	for j, v := range b {
+		if matched[j] {
+			continue
+		}
+		disp := fmt.Sprintf("%s[%d]", display, j)
+		if k, ok := keyOf(v); ok {
+			disp = fmt.Sprintf("%s[%s=%s]", display, d.arrayKey, k)
+		}
+		d.changes = append(d.changes, change{Op: "add", Path: appendPath(path, "-"), Display: disp, To: v})
+	}
+}
+
+// equal deeply compares two decoded values, treating numbers of different go types as equal if they have the same value
+func equal(a, b any) bool {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k, v := range av {
+			w, ok := bv[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bv, ok := b.([]any)
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	ai, aok := toInt(a)
+	bi, bok := toInt(b)
+	if aok && bok {
+		return ai == bi
+	}
+	af, aok := toFloat(a)
+	bf, bok := toFloat(b)
+	if aok && bok {
+		return af == bf
+	}
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	return fmt.Sprintf("%T %v", a, a) == fmt.Sprintf("%T %v", b, b)
+}
+
+// toInt returns integers exactly, large json numbers would lose their precision as floats
+func toInt(v any) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), v <= math.MaxInt64
+	case json.Number:
+		i, err := v.Int64()
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func appendPath(path []string, token string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, token)
+}
+
+// pointer renders json pointer tokens according to RFC 6901
+func pointer(path []string) string {
+	buf := &strings.Builder{}
+	for _, p := range path {
+		buf.WriteString("/")
+		p = strings.ReplaceAll(p, "~", "~0")
+		p = strings.ReplaceAll(p, "/", "~1")
+		buf.WriteString(p)
+	}
+	return buf.String()
+}
+
+var plainKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func displayKey(k string) string {
+	if plainKey.MatchString(k) {
+		return "." + k
+	}
+	return fmt.Sprintf("[%q]", k)
+}
+
+func rootDisplay(display string) string {
+	if display == "" {
+		return "."
+	}
+	return display
+}
+
+func compactJSON(v any) string {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+func writeHuman(out io.Writer, changes []change) error {
+	for _, ch := range changes {
+		var line string
+		switch ch.Op {
+		case "add":
+			line = fmt.Sprintf("+ %s: %s\n", ch.Display, compactJSON(ch.To))
+		case "remove":
+			line = fmt.Sprintf("- %s: %s\n", ch.Display, compactJSON(ch.From))
+		default:
+			line = fmt.Sprintf("~ %s: %s -> %s\n", ch.Display, compactJSON(ch.From), compactJSON(ch.To))
+		}
+		_, err := out.Write([]byte(line))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writePatch(out io.Writer, changes []change) error {
+	type operation struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value any    `json:"value,omitempty"`
+	}
+
+	ops := []operation{}
+	for _, ch := range changes {
+		op := operation{Op: ch.Op, Path: pointer(ch.Path), Value: ch.To}
+		// omitempty would otherwise drop an explicit null, which is a valid value to add or replace with
+		if ch.Op != "remove" && ch.To == nil {
+			op.Value = json.RawMessage("null")
+		}
+		ops = append(ops, op)
+	}
+
+	b, err := json.MarshalIndent(ops, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(append(b, '\n'))
+	return err
+}
+
+func writeUnified(out io.Writer, nameA, nameB string, a, b any) error {
+	ja, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+	jb, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write([]byte(unified(nameA, nameB, strings.Split(string(ja), "\n"), strings.Split(string(jb), "\n"), 3)))
+	return err
+}
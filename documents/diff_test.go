@@ -0,0 +1,165 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/urfave/cli/v3"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	a := write("a.json", `{"name":"x","n":1,"items":[{"id":1,"v":"a"},{"id":2,"v":"b"}],"gone":true}`)
+	b := write("b.yaml", "name: x\nn: 1.0\nitems:\n  - id: 2\n    v: c\n  - id: 3\n    v: d\n")
+	same := write("same.toml", "name = \"x\"\nn = 1\ngone = true\n[[items]]\nid = 1\nv = \"a\"\n[[items]]\nid = 2\nv = \"b\"\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		exitCode int
+	}{
+		{
+			name:     "equal across formats",
+			args:     []string{a, same},
+			expected: "",
+			exitCode: 0,
+		},
+		{
+			name: "human by index",
+			args: []string{a, b},
+			expected: `- .gone: true
+~ .items[0].id: 1 -> 2
+~ .items[0].v: "a" -> "c"
+~ .items[1].id: 2 -> 3
+~ .items[1].v: "b" -> "d"
+`,
+			exitCode: 1,
+		},
+		{
+			name: "human by key",
+			args: []string{"--array-key=id", a, b},
+			expected: `- .gone: true
+~ .items[id=2].v: "b" -> "c"
+- .items[id=1]: {"id":1,"v":"a"}
++ .items[id=3]: {"id":3,"v":"d"}
+`,
+			exitCode: 1,
+		},
+		{
+			name: "unified",
+			args: []string{"--output=unified", a, same},
+		},
+		{
+			name:     "missing document",
+			args:     []string{a, filepath.Join(dir, "missing.json")},
+			exitCode: 2,
+		},
+		{
+			name:     "unknown output",
+			args:     []string{"--output=xml", a, b},
+			exitCode: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exitCode := 0
+			cli.OsExiter = func(code int) { exitCode = code }
+			defer func() { cli.OsExiter = os.Exit }()
+
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Writer: out,
+				Flags:  DiffCommand.Flags,
+				Action: diff,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil && exitCode == 0 {
+				t.Errorf("diff() error = %v", err)
+				return
+			}
+			if exitCode != tt.exitCode {
+				t.Errorf("diff() exit code = %v, want %v", exitCode, tt.exitCode)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("diff() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestWritePatch(t *testing.T) {
+	d := &differ{}
+	d.compare(nil, "", map[string]any{
+		"a/b":  1.0,
+		"list": []any{1.0, 2.0, 3.0},
+	}, map[string]any{
+		"a/b":  2.0,
+		"list": []any{1.0},
+		"new":  nil,
+	})
+
+	out := &bytes.Buffer{}
+	if err := writePatch(out, d.changes); err != nil {
+		t.Fatal(err)
+	}
+
+	var ops []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &ops); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"replace /a~1b", "remove /list/2", "remove /list/1", "add /new"}
+	if len(ops) != len(expected) {
+		t.Fatalf("writePatch() got %d ops, want %d: %s", len(ops), len(expected), out.String())
+	}
+	for i, op := range ops {
+		if got := op["op"].(string) + " " + op["path"].(string); got != expected[i] {
+			t.Errorf("writePatch() op %d = %v, want %v", i, got, expected[i])
+		}
+	}
+	if _, ok := ops[3]["value"]; !ok {
+		t.Errorf("writePatch() dropped explicit null value")
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := strings.Split("a\nb\nc\nd\ne\nf\ng\nh\ni\nj", "\n")
+	b := strings.Split("a\nb\nC\nd\ne\nf\ng\nh\ni\nj\nk", "\n")
+
+	expected := `--- a
++++ b
+@@ -1,6 +1,6 @@
+ a
+ b
+-c
++C
+ d
+ e
+ f
+@@ -8,3 +8,4 @@
+ h
+ i
+ j
++k
+`
+	if got := unified("a", "b", a, b, 3); got != expected {
+		t.Errorf("unified() got = %v, want %v", got, expected)
+	}
+	if got := unified("a", "b", a, a, 3); got != "" {
+		t.Errorf("unified() of equal input got = %v, want empty", got)
+	}
+}
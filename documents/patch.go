@@ -0,0 +1,304 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/crholm/iop/conversions"
+	"github.com/urfave/cli/v3"
+	"strconv"
+	"strings"
+)
+
+func patch(ctx context.Context, c *cli.Command) error {
+	out := c.Writer
+
+	jsonPatch := c.String("json-patch")
+	merge := c.String("merge")
+	if (jsonPatch == "") == (merge == "") {
+		return errors.New("expected exactly one of --json-patch or --merge")
+	}
+
+	path := "-"
+	if c.Args().Len() > 0 {
+		path = c.Args().Get(0)
+	}
+	doc, format, err := readDocument(c, path, c.String("input-format"))
+	if err != nil {
+		return err
+	}
+
+	if jsonPatch != "" {
+		ops, _, err := readDocument(c, jsonPatch, "")
+		if err != nil {
+			return err
+		}
+		doc, err = applyJSONPatch(doc, ops)
+		if err != nil {
+			return err
+		}
+	}
+	if merge != "" {
+		m, _, err := readDocument(c, merge, "")
+		if err != nil {
+			return err
+		}
+		doc = mergePatch(doc, m)
+	}
+
+	if c.String("output-format") != "" {
+		format = c.String("output-format")
+	}
+	return conversions.Encode(format, out, doc)
+}
+
+// applyJSONPatch applies a list of RFC 6902 operations to doc
+func applyJSONPatch(doc any, patch any) (any, error) {
+	ops, ok := patch.([]any)
+	if !ok {
+		return nil, errors.New("json patch must be a list of operations")
+	}
+
+	for i, o := range ops {
+		op, ok := o.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("operation %d is not an object", i)
+		}
+		name, _ := op["op"].(string)
+		path, ok := op["path"].(string)
+		if !ok {
+			return nil, fmt.Errorf("operation %d is missing a path", i)
+		}
+		tokens, err := parsePointer(path)
+		if err != nil {
+			return nil, err
+		}
+		value, hasValue := op["value"]
+		if (name == "add" || name == "replace" || name == "test") && !hasValue {
+			return nil, fmt.Errorf("%s operation at %s is missing a value", name, path)
+		}
+
+		var fromTokens []string
+		if name == "move" || name == "copy" {
+			from, ok := op["from"].(string)
+			if !ok {
+				return nil, fmt.Errorf("%s operation at %s is missing from", name, path)
+			}
+			fromTokens, err = parsePointer(from)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		switch name {
+		case "add":
+			doc, err = pointerAdd(doc, tokens, value)
+		case "remove":
+			doc, _, err = pointerRemove(doc, tokens)
+		case "replace":
+			doc, _, err = pointerRemove(doc, tokens)
+			if err == nil {
+				doc, err = pointerAdd(doc, tokens, value)
+			}
+		case "move":
+			if strings.HasPrefix(path+"/", pointer(fromTokens)+"/") && path != pointer(fromTokens) {
+				return nil, fmt.Errorf("can not move %s into one of its children %s", pointer(fromTokens), path)
+			}
+			var v any
+			doc, v, err = pointerRemove(doc, fromTokens)
+			if err == nil {
+				doc, err = pointerAdd(doc, tokens, v)
+			}
+		case "copy":
+			var v any
+			v, err = pointerGet(doc, fromTokens)
+			if err == nil {
+				doc, err = pointerAdd(doc, tokens, deepCopy(v))
+			}
+		case "test":
+			var v any
+			v, err = pointerGet(doc, tokens)
+			if err == nil && !equal(v, value) {
+				err = fmt.Errorf("test failed at %s: expected %s, got %s", path, compactJSON(value), compactJSON(v))
+			}
+		default:
+			return nil, fmt.Errorf("unknown operation %q at %s", name, path)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// mergePatch applies an RFC 7386 json merge patch to target
+func mergePatch(target any, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+// parsePointer splits an RFC 6901 json pointer into unescaped tokens
+func parsePointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(p, "/") {
+		return nil, fmt.Errorf("invalid json pointer, must start with /: %s", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		t = strings.ReplaceAll(t, "~1", "/")
+		tokens[i] = strings.ReplaceAll(t, "~0", "~")
+	}
+	return tokens, nil
+}
+
+func arrayIndex(token string, length int, path []string) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i >= length || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index at %s", pointer(path))
+	}
+	return i, nil
+}
+
+func pointerGet(doc any, path []string) (any, error) {
+	for n, token := range path {
+		switch d := doc.(type) {
+		case map[string]any:
+			v, ok := d[token]
+			if !ok {
+				return nil, fmt.Errorf("path not found: %s", pointer(path[:n+1]))
+			}
+			doc = v
+		case []any:
+			i, err := arrayIndex(token, len(d), path[:n+1])
+			if err != nil {
+				return nil, err
+			}
+			doc = d[i]
+		default:
+			return nil, fmt.Errorf("path not found: %s", pointer(path[:n+1]))
+		}
+	}
+	return doc, nil
+}
+
+// pointerUpdate walks to the parent of path and replaces it with the result of fn, which is given the last token
+func pointerUpdate(doc any, path []string, full []string, fn func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	at := full[:len(full)-len(path)+1]
+	switch d := doc.(type) {
+	case map[string]any:
+		child, ok := d[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("path not found: %s", pointer(at))
+		}
+		child, err := pointerUpdate(child, path[1:], full, fn)
+		if err != nil {
+			return nil, err
+		}
+		d[path[0]] = child
+		return d, nil
+	case []any:
+		i, err := arrayIndex(path[0], len(d), at)
+		if err != nil {
+			return nil, err
+		}
+		child, err := pointerUpdate(d[i], path[1:], full, fn)
+		if err != nil {
+			return nil, err
+		}
+		d[i] = child
+		return d, nil
+	}
+	return nil, fmt.Errorf("path not found: %s", pointer(at))
+}
+
+func pointerAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return pointerUpdate(doc, path, path, func(parent any, token string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			p[token] = value
+			return p, nil
+		case []any:
+			i := len(p)
+			if token != "-" {
+				var err error
+				i, err = arrayIndex(token, len(p)+1, path)
+				if err != nil {
+					return nil, err
+				}
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		}
+		return nil, fmt.Errorf("path not found: %s", pointer(path))
+	})
+}
+
+func pointerRemove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	var removed any
+	doc, err := pointerUpdate(doc, path, path, func(parent any, token string) (any, error) {
+		switch p := parent.(type) {
+		case map[string]any:
+			v, ok := p[token]
+			if !ok {
+				return nil, fmt.Errorf("path not found: %s", pointer(path))
+			}
+			removed = v
+			delete(p, token)
+			return p, nil
+		case []any:
+			i, err := arrayIndex(token, len(p), path)
+			if err != nil {
+				return nil, err
+			}
+			removed = p[i]
+			return append(p[:i], p[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("path not found: %s", pointer(path))
+	})
+	return doc, removed, err
+}
+
+func deepCopy(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for k, vv := range v {
+			m[k] = deepCopy(vv)
+		}
+		return m
+	case []any:
+		l := make([]any, len(v))
+		for i, vv := range v {
+			l[i] = deepCopy(vv)
+		}
+		return l
+	}
+	return v
+}
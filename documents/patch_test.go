@@ -0,0 +1,202 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/urfave/cli/v3"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "add object member",
+			doc:      `{"foo":"bar"}`,
+			patch:    `[{"op":"add","path":"/baz","value":"qux"}]`,
+			expected: `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:     "add array element",
+			doc:      `{"foo":["bar","baz"]}`,
+			patch:    `[{"op":"add","path":"/foo/1","value":"qux"},{"op":"add","path":"/foo/-","value":"end"}]`,
+			expected: `{"foo":["bar","qux","baz","end"]}`,
+		},
+		{
+			name:     "remove and replace",
+			doc:      `{"baz":"qux","foo":["bar","qux","baz"]}`,
+			patch:    `[{"op":"remove","path":"/foo/1"},{"op":"replace","path":"/baz","value":"boo"}]`,
+			expected: `{"baz":"boo","foo":["bar","baz"]}`,
+		},
+		{
+			name:     "move and copy",
+			doc:      `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch:    `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"},{"op":"copy","from":"/qux","path":"/copy"}]`,
+			expected: `{"copy":{"corge":"grault","thud":"fred"},"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:     "escaped pointer",
+			doc:      `{"a/b":1,"m~n":2}`,
+			patch:    `[{"op":"test","path":"/a~1b","value":1},{"op":"remove","path":"/m~0n"}]`,
+			expected: `{"a/b":1}`,
+		},
+		{
+			name:    "failed test",
+			doc:     `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch:   `[{"op":"test","path":"/foo/1","value":"2"}]`,
+			wantErr: "test failed at /foo/1",
+		},
+		{
+			name:    "missing path",
+			doc:     `{"foo":"bar"}`,
+			patch:   `[{"op":"add","path":"/baz/bat","value":"qux"}]`,
+			wantErr: "path not found: /baz",
+		},
+		{
+			name:    "out of bounds",
+			doc:     `{"foo":["bar"]}`,
+			patch:   `[{"op":"remove","path":"/foo/1"}]`,
+			wantErr: "invalid array index at /foo/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc, patch any
+			if err := json.Unmarshal([]byte(tt.doc), &doc); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tt.patch), &patch); err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := applyJSONPatch(doc, patch)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("applyJSONPatch() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("applyJSONPatch() error = %v", err)
+				return
+			}
+			if got := compactJSON(res); got != tt.expected {
+				t.Errorf("applyJSONPatch() got = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	var target, patch any
+	_ = json.Unmarshal([]byte(`{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"]}`), &target)
+	_ = json.Unmarshal([]byte(`{"title":"Hello!","phoneNumber":"+01-123-456-7890","author":{"familyName":null},"tags":["example"]}`), &patch)
+
+	expected := `{"author":{"givenName":"John"},"phoneNumber":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`
+	if got := compactJSON(mergePatch(target, patch)); got != expected {
+		t.Errorf("mergePatch() got = %v, want %v", got, expected)
+	}
+}
+
+func TestPatch(t *testing.T) {
+	dir := t.TempDir()
+	merge := filepath.Join(dir, "merge.yaml")
+	if err := os.WriteFile(merge, []byte("name: bob\nlist: null\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	in := strings.NewReader(`{"name":"x","list":[1,2]}`)
+	out := &bytes.Buffer{}
+	cmd := &cli.Command{
+		Reader: in,
+		Writer: out,
+		Flags:  PatchCommand.Flags,
+		Action: patch,
+	}
+	err := cmd.Run(context.Background(), []string{"", "--merge", merge, "--output-format", "yaml"})
+	if err != nil {
+		t.Errorf("patch() error = %v", err)
+		return
+	}
+	if out.String() != "name: bob\n" {
+		t.Errorf("patch() got = %v, want %v", out.String(), "name: bob\n")
+	}
+}
+
+func TestPatchFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		args     []string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "large integers keep their precision",
+			doc:      `{"id":9007199254740993}`,
+			patch:    `[{"op":"test","path":"/id","value":9007199254740993},{"op":"add","path":"/next","value":9007199254740995}]`,
+			args:     []string{"--input-format", "json"},
+			expected: "{\n  \"id\": 9007199254740993,\n  \"next\": 9007199254740995\n}\n",
+		},
+		{
+			name:     "json integers stay integers in toml",
+			doc:      "n = 1\n",
+			patch:    `[{"op":"add","path":"/m","value":9},{"op":"add","path":"/f","value":1.5}]`,
+			args:     []string{"--input-format", "toml"},
+			expected: "f = 1.5\nm = 9\nn = 1\n",
+		},
+		{
+			name:    "xml output is not supported",
+			doc:     `{"a":1}`,
+			patch:   `[]`,
+			args:    []string{"--output-format", "xml"},
+			wantErr: `unsupported output format: "xml"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patchFile := filepath.Join(t.TempDir(), "patch.json")
+			if err := os.WriteFile(patchFile, []byte(tt.patch), 0644); err != nil {
+				t.Fatal(err)
+			}
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.doc),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "json-patch"},
+					&cli.StringFlag{Name: "merge"},
+					&cli.StringFlag{Name: "input-format"},
+					&cli.StringFlag{Name: "output-format"},
+				},
+				Action: patch,
+			}
+			err := cmd.Run(context.Background(), append([]string{"", "--json-patch", patchFile}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("patch() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("patch() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("patch() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -1,13 +1,23 @@
 package encoders
 
 import (
+	"github.com/crholm/iop/highlight"
+	"github.com/crholm/iop/utils"
 	"github.com/urfave/cli/v3"
 )
 
 var Commands = []*cli.Command{
 	{
-		Name:   "url",
-		Usage:  "url query encodes a data",
+		Name:  "url",
+		Usage: "url encodes data, by the rules of the part of the url it is for",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "mode",
+				Aliases: []string{"m"},
+				Value:   "query",
+				Usage:   "query, escaping spaces as +, path for a path segment, fragment or userinfo for a user name or password",
+			},
+		},
 		Action: urlEncode,
 	},
 	{
@@ -25,6 +35,14 @@ var Commands = []*cli.Command{
 				Name:  "url",
 				Usage: "url encoding",
 			},
+			&cli.BoolFlag{
+				Name:  "raw",
+				Usage: "no padding",
+			},
+			&cli.IntFlag{
+				Name:  "wrap",
+				Usage: "wrap lines at N characters, eg. 76 for MIME or 64 for PEM, 0 does not wrap",
+			},
 		},
 		Action: base64Encode,
 	},
@@ -37,16 +55,194 @@ var Commands = []*cli.Command{
 				Name:  "hex",
 				Usage: "hex version",
 			},
+			&cli.BoolFlag{
+				Name:  "raw",
+				Usage: "no padding",
+			},
+			&cli.IntFlag{
+				Name:  "wrap",
+				Usage: "wrap lines at N characters, eg. 76 for MIME or 64 for PEM, 0 does not wrap",
+			},
 		},
 		Action: base32Encode,
 	},
+	{
+		Name:    "b58",
+		Aliases: []string{"base58"},
+		Usage:   "base58 encodes data",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "alphabet",
+				Value: "bitcoin",
+				Usage: "bitcoin, flickr or ripple",
+			},
+		},
+		Action: base58Encode,
+	},
+	{
+		Name:    "b58check",
+		Aliases: []string{"base58check"},
+		Usage:   "base58 encodes a version byte and data, followed by a 4 byte double sha256 checksum",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "version",
+				Usage: "version byte, eg. 0 for bitcoin addresses",
+			},
+			&cli.StringFlag{
+				Name:  "alphabet",
+				Value: "bitcoin",
+				Usage: "bitcoin, flickr or ripple",
+			},
+		},
+		Action: base58CheckEncode,
+	},
+	{
+		Name:    "b62",
+		Aliases: []string{"base62"},
+		Usage:   "base62 encodes data",
+		Action:  base62Encode,
+	},
+	{
+		Name:    "a85",
+		Aliases: []string{"ascii85"},
+		Usage:   "ascii85 encodes data, the adobe variant",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "delimiters",
+				Aliases: []string{"d"},
+				Usage:   "wraps the output in <~ and ~>",
+			},
+		},
+		Action: ascii85Encode,
+	},
+	{
+		Name:   "z85",
+		Usage:  "z85 encodes data, the zeromq variant, input length has to be a multiple of 4",
+		Action: base85Encoder(utils.Z85),
+	},
+	{
+		Name:    "b85",
+		Aliases: []string{"base85"},
+		Usage:   "base85 encodes data with the RFC 1924 alphabet, as git does",
+		Action:  base85Encoder(utils.B85),
+	},
+	{
+		Name:    "b45",
+		Aliases: []string{"base45"},
+		Usage:   "base45 encodes data, RFC 9285",
+		Action:  base45Encode,
+	},
+	{
+		Name:    "b36",
+		Aliases: []string{"base36"},
+		Usage:   "base36 encodes data",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "uppercase",
+				Aliases: []string{"u"},
+			},
+		},
+		Action: base36Encode,
+	},
+	{
+		Name:  "bech32",
+		Usage: "bech32 or bech32m encodes data with a human readable prefix, BIP-173 and BIP-350",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "hrp",
+				Usage:    "human readable part, eg. bc or tb",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "variant",
+				Value: "bech32",
+				Usage: "bech32 or bech32m, defaults to the variant of the witness version when it is given",
+			},
+			&cli.IntFlag{
+				Name:  "witness-version",
+				Value: -1,
+				Usage: "prepends a segwit witness version, 0 uses bech32 and 1-16 bech32m, as addresses do",
+			},
+		},
+		Action: bech32Encode,
+	},
 	{
 		Name:    "hex",
 		Aliases: []string{"0x"},
 		Usage:   "hex encodes a data",
 		Action:  hexEncode,
 	},
+	{
+		Name:    "hexdump",
+		Aliases: []string{"xxd"},
+		Usage:   "dumps data as lines of offset, hex bytes and ascii, like xxd or hexdump -C",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "canonical",
+				Aliases: []string{"C"},
+				Usage:   "hexdump -C layout",
+			},
+			&cli.IntFlag{
+				Name:    "cols",
+				Aliases: []string{"c"},
+				Value:   16,
+				Usage:   "bytes per line",
+			},
+			&cli.IntFlag{
+				Name:    "group",
+				Aliases: []string{"g"},
+				Usage:   "bytes per group, defaults to 2, or 1 with --canonical",
+			},
+			&cli.BoolFlag{
+				Name:    "uppercase",
+				Aliases: []string{"u"},
+			},
+			&cli.StringFlag{
+				Name:    "separator",
+				Aliases: []string{"s"},
+				Value:   " ",
+				Usage:   "separator between groups",
+			},
+		},
+		Action: highlight.Wrap("hexdump", encodeHexdump),
+	},
 
+	{
+		Name:    "datauri",
+		Aliases: []string{"data-uri"},
+		Usage:   "encodes data as a base64 data: uri, RFC 2397, eg. for inline images in css and html",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "type",
+				Aliases: []string{"t"},
+				Usage:   "media type, eg. image/svg+xml, sniffed from the data if not given",
+			},
+		},
+		Action: encodeDataURI,
+	},
+	{
+		Name:  "punycode",
+		Usage: "punycode encodes a domain label, RFC 3492, ascii labels are left as they are. Use conv idna for full domain names",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "prefix",
+				Usage: "add the xn-- prefix of idna",
+			},
+		},
+		Action: punycodeEncode,
+	},
+	{
+		Name:    "qp",
+		Aliases: []string{"quoted-printable"},
+		Usage:   "quoted-printable encodes mime bodies, RFC 2045",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "binary",
+				Usage: "encode line breaks as =0D=0A instead of keeping them as CRLF",
+			},
+		},
+		Action: encodeQuotedPrintable,
+	},
 	{
 		Name:  "mime",
 		Usage: "encode text as mime headers RFC 2047, ascii representations of encoded words",
@@ -64,4 +260,49 @@ var Commands = []*cli.Command{
 		},
 		Action: encodeMIME,
 	},
+	{
+		Name:  "html",
+		Usage: "escapes html special characters as entities",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "ascii",
+				Usage: "escapes non ascii characters as numeric entities",
+			},
+			&cli.BoolFlag{
+				Name:  "hex",
+				Usage: "uses hex rather than decimal numeric entities, with --ascii",
+			},
+		},
+		Action: encodeHTML,
+	},
+	{
+		Name:  "literal",
+		Usage: "escapes data as a string literal of a programming language, binary data as a byte array",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "lang",
+				Aliases: []string{"l"},
+				Value:   "go",
+				Usage:   "go, c, python, js, java, json, shell or sql",
+			},
+			&cli.BoolFlag{
+				Name:  "raw",
+				Usage: "uses a raw string literal when the input allows it, for go, python and js",
+			},
+			&cli.BoolFlag{
+				Name:  "ascii",
+				Usage: "escapes non ascii characters",
+			},
+			&cli.BoolFlag{
+				Name:  "bytes",
+				Usage: "writes a byte array even if the input is valid utf-8",
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Value: "data",
+				Usage: "variable name of c byte arrays",
+			},
+		},
+		Action: encodeLiteral,
+	},
 }
@@ -2,14 +2,21 @@ package encoders
 
 import (
 	"context"
+	"encoding/ascii85"
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"github.com/crholm/iop/utils"
 	"github.com/urfave/cli/v3"
+	"golang.org/x/net/idna"
+	"html"
 	"io"
 	"mime"
+	"mime/quotedprintable"
+	"net/http"
 	"net/url"
+	"strings"
 )
 
 func urlEncode(ctx context.Context, c *cli.Command) error {
@@ -21,7 +28,19 @@ func urlEncode(ctx context.Context, c *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	s := url.QueryEscape(string(b))
+	var s string
+	switch mode := c.String("mode"); mode {
+	case "query", "":
+		s = url.QueryEscape(string(b))
+	case "path":
+		s = url.PathEscape(string(b))
+	case "fragment":
+		s = (&url.URL{Fragment: string(b)}).EscapedFragment()
+	case "userinfo":
+		s = url.User(string(b)).String()
+	default:
+		return fmt.Errorf("unknown mode %s, expected path, query, fragment or userinfo", mode)
+	}
 	_, err = out.Write([]byte(s))
 
 	return err
@@ -44,6 +63,41 @@ func binaryEncode(ctx context.Context, c *cli.Command) error {
 	return err
 }
 
+// lineWrapper breaks output into lines of width characters, like PEM and MIME do with base64
+type lineWrapper struct {
+	w     io.Writer
+	width int
+	col   int
+}
+
+func wrapLines(w io.Writer, width int) io.Writer {
+	if width <= 0 {
+		return w
+	}
+	return &lineWrapper{w: w, width: width}
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if l.col == l.width {
+			if _, err := l.w.Write([]byte{'\n'}); err != nil {
+				return n, err
+			}
+			l.col = 0
+		}
+		chunk := min(len(p), l.width-l.col)
+		m, err := l.w.Write(p[:chunk])
+		n += m
+		l.col += m
+		if err != nil {
+			return n, err
+		}
+		p = p[chunk:]
+	}
+	return n, nil
+}
+
 func base64Encode(ctx context.Context, c *cli.Command) error {
 
 	in := c.Reader
@@ -53,7 +107,10 @@ func base64Encode(ctx context.Context, c *cli.Command) error {
 	if c.Bool("url") {
 		e = base64.URLEncoding
 	}
-	encoder := base64.NewEncoder(e, out)
+	if c.Bool("raw") {
+		e = e.WithPadding(base64.NoPadding)
+	}
+	encoder := base64.NewEncoder(e, wrapLines(out, int(c.Int("wrap"))))
 	_, err := io.Copy(encoder, in)
 	if err != nil {
 		return err
@@ -70,7 +127,10 @@ func base32Encode(ctx context.Context, c *cli.Command) error {
 	if c.Bool("hex") {
 		e = base32.HexEncoding
 	}
-	encoder := base32.NewEncoder(e, out)
+	if c.Bool("raw") {
+		e = e.WithPadding(base32.NoPadding)
+	}
+	encoder := base32.NewEncoder(e, wrapLines(out, int(c.Int("wrap"))))
 	_, err := io.Copy(encoder, in)
 	if err != nil {
 		return err
@@ -113,3 +173,245 @@ func encodeMIME(ctx context.Context, command *cli.Command) error {
 	return err
 	
 }
+
+func encodeHTML(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	s := html.EscapeString(string(b))
+	if c.Bool("ascii") {
+		format := "&#%d;"
+		if c.Bool("hex") {
+			format = "&#x%X;"
+		}
+		sb := strings.Builder{}
+		for _, r := range s {
+			if r < 0x80 {
+				sb.WriteRune(r)
+				continue
+			}
+			sb.WriteString(fmt.Sprintf(format, r))
+		}
+		s = sb.String()
+	}
+
+	_, err = io.WriteString(out, s)
+	return err
+}
+
+func base58Alphabet(c *cli.Command) (*utils.BaseX, error) {
+	b, ok := utils.Base58Alphabets[c.String("alphabet")]
+	if !ok {
+		return nil, fmt.Errorf("unknown base58 alphabet %s, expected bitcoin, flickr or ripple", c.String("alphabet"))
+	}
+	return b, nil
+}
+
+func base58Encode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := base58Alphabet(c)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, b.Encode(data))
+	return err
+}
+
+func base58CheckEncode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := base58Alphabet(c)
+	if err != nil {
+		return err
+	}
+	version := c.Int("version")
+	if version < 0 || version > 255 {
+		return fmt.Errorf("version has to be a byte, 0-255, got %d", version)
+	}
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, utils.Base58CheckEncode(b, byte(version), data))
+	return err
+}
+
+func base62Encode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, utils.Base62.Encode(data))
+	return err
+}
+
+func ascii85Encode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	delimiters := c.Bool("delimiters")
+	if delimiters {
+		if _, err := io.WriteString(out, "<~"); err != nil {
+			return err
+		}
+	}
+	encoder := ascii85.NewEncoder(out)
+	_, err := io.Copy(encoder, in)
+	if err != nil {
+		return err
+	}
+	err = encoder.Close()
+	if err != nil {
+		return err
+	}
+	if delimiters {
+		_, err = io.WriteString(out, "~>")
+	}
+	return err
+}
+
+func base85Encoder(enc *utils.Base85) cli.ActionFunc {
+	return func(ctx context.Context, c *cli.Command) error {
+		in := c.Reader
+		out := c.Writer
+
+		encoder := utils.NewBase85Encoder(enc, out)
+		_, err := io.Copy(encoder, in)
+		if err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+}
+
+func base36Encode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	s := utils.Base36.Encode(data)
+	if c.Bool("uppercase") {
+		s = strings.ToUpper(s)
+	}
+	_, err = io.WriteString(out, s)
+	return err
+}
+
+func base45Encode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, utils.Base45Encode(data))
+	return err
+}
+
+func bech32Encode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	values, err := utils.ConvertBits(data, 8, 5, true)
+	if err != nil {
+		return err
+	}
+	variant := c.String("variant")
+	if version := c.Int("witness-version"); version >= 0 {
+		if version > 16 {
+			return fmt.Errorf("witness version has to be 0-16, got %d", version)
+		}
+		segwit := utils.SegwitVariant(byte(version))
+		if c.IsSet("variant") && variant != segwit {
+			return fmt.Errorf("witness version %d uses %s, not %s", version, segwit, variant)
+		}
+		variant = segwit
+		values = append([]byte{byte(version)}, values...)
+	}
+
+	s, err := utils.Bech32Encode(c.String("hrp"), values, variant)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, s)
+	return err
+}
+
+func encodeQuotedPrintable(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	w := quotedprintable.NewWriter(out)
+	w.Binary = c.Bool("binary")
+	_, err := io.Copy(w, in)
+	if err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func encodeDataURI(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	contentType := c.String("type")
+	if contentType == "" {
+		contentType = http.DetectContentType(b)
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("invalid media type %s: %w", contentType, err)
+	}
+	// data uris separate parameters by ; without white space
+	t := strings.ReplaceAll(mime.FormatMediaType(mediaType, params), "; ", ";")
+
+	_, err = io.WriteString(out, "data:"+t+";base64,"+base64.StdEncoding.EncodeToString(b))
+	return err
+}
+
+func punycodeEncode(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	// the punycode profile does no mapping or validation, only the encoding of labels that are not ascii
+	s, err := idna.Punycode.ToASCII(strings.TrimRight(string(b), "\r\n"))
+	if err != nil {
+		return err
+	}
+	if !c.Bool("prefix") {
+		s = strings.TrimPrefix(s, "xn--")
+	}
+	_, err = io.WriteString(out, s)
+	return err
+}
@@ -3,6 +3,7 @@ package encoders
 import (
 	"bytes"
 	"context"
+	"github.com/crholm/iop/utils"
 	"github.com/urfave/cli/v3"
 	"strings"
 	"testing"
@@ -281,3 +282,398 @@ func TestHexEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+	}{
+		{
+			name:     "special characters",
+			input:    `<a href="x">Tom & 'Jerry'</a>`,
+			expected: "&lt;a href=&#34;x&#34;&gt;Tom &amp; &#39;Jerry&#39;&lt;/a&gt;",
+		},
+		{
+			name:     "non ascii is kept",
+			input:    "Räksmörgås",
+			expected: "Räksmörgås",
+		},
+		{
+			name:     "ascii decimal",
+			args:     []string{"--ascii"},
+			input:    "Rä 😀",
+			expected: "R&#228; &#128512;",
+		},
+		{
+			name:     "ascii hex",
+			args:     []string{"--ascii", "--hex"},
+			input:    "Rä <",
+			expected: "R&#xE4; &lt;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "ascii"},
+					&cli.BoolFlag{Name: "hex"},
+				},
+				Action: encodeHTML,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil {
+				t.Errorf("encodeHTML() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("encodeHTML() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestBase58Encode(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   cli.ActionFunc
+		args     []string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "bitcoin", action: base58Encode, input: "Hello World!", expected: "2NEpo7TZRRrLZSi2U"},
+		{name: "leading zeros", action: base58Encode, input: "\x00\x00\x28\x7f\xb4\xcd", expected: "11233QC4"},
+		{name: "unknown alphabet", action: base58Encode, args: []string{"--alphabet", "dogecoin"}, input: "x", wantErr: true},
+		{name: "check", action: base58CheckEncode, input: strings.Repeat("\x00", 20), expected: "1111111111111111111114oLvT2"},
+		{name: "check ripple", action: base58CheckEncode, args: []string{"--alphabet", "ripple"}, input: strings.Repeat("\x00", 20), expected: "rrrrrrrrrrrrrrrrrrrrrhoLvTp"},
+		{name: "check version out of range", action: base58CheckEncode, args: []string{"--version", "256"}, input: "x", wantErr: true},
+		{name: "base62", action: base62Encode, input: "\x00\x01\x00", expected: "048"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "alphabet", Value: "bitcoin"},
+					&cli.IntFlag{Name: "version"},
+				},
+				Action: tt.action,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("base58Encode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("base58Encode() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestBase85Encode(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   cli.ActionFunc
+		args     []string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "a85", action: ascii85Encode, input: "hello world\x00\x00\x00\x00", expected: "BOu!rD]j7BEbo7d!!!!"},
+		{name: "a85 delimiters", action: ascii85Encode, args: []string{"--delimiters"}, input: "\x00\x00\x00\x00", expected: "<~z~>"},
+		{name: "z85", action: base85Encoder(utils.Z85), input: "\x86\x4f\xd2\x6f\xb5\x59\xf7\x5b", expected: "HelloWorld"},
+		{name: "z85 length", action: base85Encoder(utils.Z85), input: "abc", wantErr: true},
+		{name: "b85", action: base85Encoder(utils.B85), input: "hello", expected: "Xk~0{Zv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "delimiters"},
+				},
+				Action: tt.action,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("base85Encode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("base85Encode() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestBase36And45Encode(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   cli.ActionFunc
+		args     []string
+		input    string
+		expected string
+	}{
+		{name: "base36", action: base36Encode, input: "\x00\x00hello", expected: "005pzcszu7"},
+		{name: "base36 uppercase", action: base36Encode, args: []string{"--uppercase"}, input: "hello", expected: "5PZCSZU7"},
+		{name: "base45", action: base45Encode, input: "Hello!!", expected: "%69 VD92EX0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "uppercase"},
+				},
+				Action: tt.action,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil {
+				t.Errorf("encode error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("encode got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestBech32Encode(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "segwit v0", args: []string{"--hrp", "bc", "--witness-version", "0"}, input: "\x75\x1e\x76\xe8\x19\x91\x96\xd4\x54\x94\x1c\x45\xd1\xb3\xa3\x23\xf1\x43\x3b\xd6", expected: "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{name: "segwit v16 bech32m", args: []string{"--hrp", "BC", "--variant", "bech32m", "--witness-version", "16"}, input: "\x75\x1e", expected: "bc1sw50qgdz25j"},
+		{name: "segwit v1 defaults to bech32m", args: []string{"--hrp", "bc", "--witness-version", "1"}, input: "\x75\x1e", expected: "bc1pw50q7ulhnr"},
+		{name: "segwit v1 with bech32", args: []string{"--hrp", "bc", "--variant", "bech32", "--witness-version", "1"}, input: "\x75\x1e", wantErr: true},
+		{name: "segwit v0 with bech32m", args: []string{"--hrp", "bc", "--variant", "bech32m", "--witness-version", "0"}, input: "\x75\x1e", wantErr: true},
+		{name: "empty", args: []string{"--hrp", "a"}, input: "", expected: "a12uel5l"},
+		{name: "unknown variant", args: []string{"--hrp", "a", "--variant", "bech64"}, wantErr: true},
+		{name: "witness version", args: []string{"--hrp", "bc", "--witness-version", "17"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "hrp"},
+					&cli.StringFlag{Name: "variant", Value: "bech32"},
+					&cli.IntFlag{Name: "witness-version", Value: -1},
+				},
+				Action: bech32Encode,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("bech32Encode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("bech32Encode() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestBase64And32Variants(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   cli.ActionFunc
+		args     []string
+		input    string
+		expected string
+	}{
+		{name: "base64 raw", action: base64Encode, args: []string{"--raw"}, input: "Hello World", expected: "SGVsbG8gV29ybGQ"},
+		{name: "base64 raw url", action: base64Encode, args: []string{"--raw", "--url"}, input: "Hello World?", expected: "SGVsbG8gV29ybGQ_"},
+		{name: "base64 wrap", action: base64Encode, args: []string{"--wrap", "8"}, input: "Hello World", expected: "SGVsbG8g\nV29ybGQ="},
+		{name: "base64 wrap exact", action: base64Encode, args: []string{"--wrap", "4"}, input: "Hello!", expected: "SGVs\nbG8h"},
+		{name: "base32 raw", action: base32Encode, args: []string{"--raw"}, input: "Hello World", expected: "JBSWY3DPEBLW64TMMQ"},
+		{name: "base32 wrap", action: base32Encode, args: []string{"--wrap", "10"}, input: "Hello World", expected: "JBSWY3DPEB\nLW64TMMQ==\n===="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "url"},
+					&cli.BoolFlag{Name: "hex"},
+					&cli.BoolFlag{Name: "raw"},
+					&cli.IntFlag{Name: "wrap"},
+				},
+				Action: tt.action,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil {
+				t.Errorf("encode error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("encode got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestEncodeQuotedPrintable(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+	}{
+		{name: "utf-8", input: "Hej där", expected: "Hej d=C3=A4r"},
+		{name: "equals and trailing space", input: "a=b \nc", expected: "a=3Db=20\r\nc"},
+		{name: "soft line break", input: strings.Repeat("x", 80), expected: strings.Repeat("x", 75) + "=\r\nxxxxx"},
+		{name: "binary", args: []string{"--binary"}, input: "a\nb", expected: "a=0Ab"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags:  []cli.Flag{&cli.BoolFlag{Name: "binary"}},
+				Action: encodeQuotedPrintable,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil {
+				t.Errorf("encodeQuotedPrintable() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("encodeQuotedPrintable() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestEncodeDataURI(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "sniffed png", input: "\x89PNG\r\n\x1a\n0000", expected: "data:image/png;base64,iVBORw0KGgowMDAw"},
+		{name: "sniffed text", input: "hi", expected: "data:text/plain;charset=utf-8;base64,aGk="},
+		{name: "given type", args: []string{"--type", "image/svg+xml"}, input: "<svg/>", expected: "data:image/svg+xml;base64,PHN2Zy8+"},
+		{name: "given type with params", args: []string{"--type", "text/css; charset=UTF-8"}, input: "a{}", expected: "data:text/css;charset=UTF-8;base64,YXt9"},
+		{name: "invalid type", args: []string{"--type", "text/"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags:  []cli.Flag{&cli.StringFlag{Name: "type"}},
+				Action: encodeDataURI,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("encodeDataURI() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("encodeDataURI() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestUrlEncodeModes(t *testing.T) {
+	tests := []struct {
+		mode     string
+		expected string
+		wantErr  bool
+	}{
+		{mode: "query", expected: "a+b%2Fc%2Bd%3Fe%23f%40g%3Ah"},
+		{mode: "path", expected: "a%20b%2Fc+d%3Fe%23f@g:h"},
+		{mode: "fragment", expected: "a%20b/c+d?e%23f@g:h"},
+		{mode: "userinfo", expected: "a%20b%2Fc+d%3Fe%23f%40g%3Ah"},
+		{mode: "host", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader("a b/c+d?e#f@g:h"),
+				Writer: out,
+				Flags:  []cli.Flag{&cli.StringFlag{Name: "mode"}},
+				Action: urlEncode,
+			}
+			err := cmd.Run(context.Background(), []string{"", "--mode", tt.mode})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("urlEncode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("urlEncode() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestPunycodeEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+	}{
+		{name: "label", input: "münchen\n", expected: "mnchen-3ya"},
+		{name: "prefix", args: []string{"--prefix"}, input: "bücher", expected: "xn--bcher-kva"},
+		{name: "ascii is left as is", args: []string{"--prefix"}, input: "abc", expected: "abc"},
+		{name: "rfc 3492 sample", input: "安室奈美恵-with-SUPER-MONKEYS", expected: "-with-SUPER-MONKEYS-pc58ag80a8qai00g7n9n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags:  []cli.Flag{&cli.BoolFlag{Name: "prefix"}},
+				Action: punycodeEncode,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil {
+				t.Errorf("punycodeEncode() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("punycodeEncode() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
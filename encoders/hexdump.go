@@ -0,0 +1,117 @@
+package encoders
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"io"
+	"strings"
+)
+
+// hexdumper writes lines of offset, grouped hex bytes and an ascii gutter, like xxd or hexdump -C
+type hexdumper struct {
+	cols      int
+	group     int
+	separator string
+	upper     bool
+	canonical bool // hexdump -C, with an extra space every 8 bytes, the gutter between | and a last offset line
+}
+
+func (h hexdumper) hexWidth() int {
+	groups := (h.cols + h.group - 1) / h.group
+	width := h.cols*2 + (groups-1)*len(h.separator)
+	if h.canonical {
+		width += (h.cols - 1) / 8
+	}
+	return width
+}
+
+func (h hexdumper) line(offset int, b []byte) string {
+	sb := strings.Builder{}
+	if h.canonical {
+		sb.WriteString(fmt.Sprintf("%08x  ", offset))
+	} else {
+		sb.WriteString(fmt.Sprintf("%08x: ", offset))
+	}
+
+	format := "%02x"
+	if h.upper {
+		format = "%02X"
+	}
+	hex := strings.Builder{}
+	for i, c := range b {
+		if i > 0 && i%h.group == 0 {
+			hex.WriteString(h.separator)
+		}
+		if h.canonical && i > 0 && i%8 == 0 {
+			hex.WriteString(" ")
+		}
+		hex.WriteString(fmt.Sprintf(format, c))
+	}
+	sb.WriteString(hex.String())
+	sb.WriteString(strings.Repeat(" ", h.hexWidth()-hex.Len()))
+
+	gutter := make([]byte, len(b))
+	for i, c := range b {
+		gutter[i] = c
+		if c < 0x20 || c > 0x7e {
+			gutter[i] = '.'
+		}
+	}
+	if h.canonical {
+		sb.WriteString("  |" + string(gutter) + "|")
+	} else {
+		sb.WriteString("  " + string(gutter))
+	}
+	return sb.String()
+}
+
+func (h hexdumper) dump(in io.Reader, out io.Writer) error {
+	w := bufio.NewWriter(out)
+	buf := make([]byte, h.cols)
+	offset := 0
+	for {
+		n, err := io.ReadFull(in, buf)
+		if n > 0 {
+			w.WriteString(h.line(offset, buf[:n]) + "\n")
+			offset += n
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if h.canonical && offset > 0 {
+		w.WriteString(fmt.Sprintf("%08x\n", offset))
+	}
+	return w.Flush()
+}
+
+func encodeHexdump(ctx context.Context, c *cli.Command) error {
+	h := hexdumper{
+		cols:      int(c.Int("cols")),
+		group:     int(c.Int("group")),
+		separator: c.String("separator"),
+		upper:     c.Bool("uppercase"),
+		canonical: c.Bool("canonical"),
+	}
+	if h.cols < 1 {
+		return fmt.Errorf("--cols has to be at least 1, got %d", h.cols)
+	}
+	if h.group == 0 {
+		// the defaults of xxd and hexdump -C
+		h.group = 2
+		if h.canonical {
+			h.group = 1
+		}
+	}
+	if h.group < 1 {
+		return fmt.Errorf("--group has to be at least 1, got %d", h.group)
+	}
+
+	return h.dump(c.Reader, c.Writer)
+}
@@ -0,0 +1,81 @@
+package encoders
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestEncodeHexdump(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "xxd",
+			input:    "Hello World\nand more bytes\x00\x01\xff!",
+			expected: "00000000: 4865 6c6c 6f20 576f 726c 640a 616e 6420  Hello World.and \n00000010: 6d6f 7265 2062 7974 6573 0001 ff21       more bytes...!\n",
+		},
+		{
+			name:     "canonical",
+			args:     []string{"-C"},
+			input:    "Hello World\nand more bytes\x00\x01\xff!",
+			expected: "00000000  48 65 6c 6c 6f 20 57 6f  72 6c 64 0a 61 6e 64 20  |Hello World.and |\n00000010  6d 6f 72 65 20 62 79 74  65 73 00 01 ff 21        |more bytes...!|\n0000001e\n",
+		},
+		{
+			name:     "cols, group, uppercase and separator",
+			args:     []string{"--cols", "4", "--group", "1", "--uppercase", "--separator", ":"},
+			input:    "Hello",
+			expected: "00000000: 48:65:6C:6C  Hell\n00000004: 6F           o\n",
+		},
+		{
+			name:     "uneven groups",
+			args:     []string{"--cols", "5", "--group", "2"},
+			input:    "abcdefg",
+			expected: "00000000: 6162 6364 65  abcde\n00000005: 6667          fg\n",
+		},
+		{
+			name:     "empty",
+			args:     []string{"-C"},
+			input:    "",
+			expected: "",
+		},
+		{
+			name:    "no cols",
+			args:    []string{"--cols", "0"},
+			input:   "x",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{Name: "canonical", Aliases: []string{"C"}},
+					&cli.IntFlag{Name: "cols", Value: 16},
+					&cli.IntFlag{Name: "group"},
+					&cli.BoolFlag{Name: "uppercase"},
+					&cli.StringFlag{Name: "separator", Value: " "},
+				},
+				Action: encodeHexdump,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("encodeHexdump() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("encodeHexdump() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
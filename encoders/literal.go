@@ -0,0 +1,343 @@
+package encoders
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// literalBytesPerLine is the number of bytes per line of byte array literals, the same as xxd -i
+const literalBytesPerLine = 12
+
+// literalEscaper escapes a string into a quoted literal
+type literalEscaper struct {
+	quote   string
+	escapes map[rune]string
+	control func(r rune) string // control characters, and anything else that is not printable
+	ascii   func(r rune) string // non ascii characters, when --ascii is given
+}
+
+func (e literalEscaper) escape(s string, ascii bool) string {
+	sb := strings.Builder{}
+	sb.WriteString(e.quote)
+	for _, r := range s {
+		switch {
+		case e.escapes[r] != "":
+			sb.WriteString(e.escapes[r])
+		case r < 0x20 || r == 0x7f || (r >= 0x80 && !unicode.IsPrint(r) && !unicode.IsSpace(r)):
+			sb.WriteString(e.control(r))
+		case r >= 0x80 && ascii:
+			sb.WriteString(e.ascii(r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteString(e.quote)
+	return sb.String()
+}
+
+func octalEscape(r rune) string {
+	sb := strings.Builder{}
+	for _, b := range []byte(string(r)) {
+		sb.WriteString(fmt.Sprintf("\\%03o", b))
+	}
+	return sb.String()
+}
+
+func hexEscape(r rune) string {
+	if r < 0x100 {
+		return fmt.Sprintf("\\x%02x", r)
+	}
+	return utf16Escape(r)
+}
+
+func utf8HexEscape(r rune) string {
+	sb := strings.Builder{}
+	for _, b := range []byte(string(r)) {
+		sb.WriteString(fmt.Sprintf("\\x%02x", b))
+	}
+	return sb.String()
+}
+
+func utf16Escape(r rune) string {
+	if r < 0x10000 {
+		return fmt.Sprintf("\\u%04x", r)
+	}
+	r1, r2 := utf16.EncodeRune(r)
+	return fmt.Sprintf("\\u%04x\\u%04x", r1, r2)
+}
+
+func pythonEscape(r rune) string {
+	switch {
+	case r < 0x100:
+		return fmt.Sprintf("\\x%02x", r)
+	case r < 0x10000:
+		return fmt.Sprintf("\\u%04x", r)
+	}
+	return fmt.Sprintf("\\U%08x", r)
+}
+
+var (
+	cEscaper = literalEscaper{
+		quote: `"`,
+		escapes: map[rune]string{
+			'"': `\"`, '\\': `\\`, '\n': `\n`, '\t': `\t`, '\r': `\r`, '\a': `\a`, '\b': `\b`, '\f': `\f`, '\v': `\v`,
+		},
+		control: octalEscape,
+		ascii:   octalEscape,
+	}
+	javaEscaper = literalEscaper{
+		quote: `"`,
+		escapes: map[rune]string{
+			'"': `\"`, '\\': `\\`, '\n': `\n`, '\t': `\t`, '\r': `\r`, '\b': `\b`, '\f': `\f`,
+		},
+		control: func(r rune) string {
+			if r < 0x100 {
+				return fmt.Sprintf("\\%03o", r)
+			}
+			return utf16Escape(r)
+		},
+		ascii: utf16Escape,
+	}
+	jsEscaper = literalEscaper{
+		quote: `"`,
+		escapes: map[rune]string{
+			'"': `\"`, '\\': `\\`, '\n': `\n`, '\t': `\t`, '\r': `\r`, '\b': `\b`, '\f': `\f`, '\v': `\v`,
+			'\u2028': `\u2028`, '\u2029': `\u2029`,
+		},
+		control: hexEscape,
+		ascii:   utf16Escape,
+	}
+	shellEscaper = literalEscaper{
+		quote: `'`,
+		escapes: map[rune]string{
+			'\'': `\'`, '\\': `\\`, '\n': `\n`, '\t': `\t`, '\r': `\r`, '\a': `\a`, '\b': `\b`, '\f': `\f`, '\v': `\v`,
+			0x1b: `\e`,
+		},
+		control: utf8HexEscape,
+		ascii:   utf8HexEscape,
+	}
+)
+
+func pythonEscaper(s string) literalEscaper {
+	// the same choice of quotes as repr()
+	quote := "'"
+	if strings.Contains(s, "'") && !strings.Contains(s, `"`) {
+		quote = `"`
+	}
+	e := literalEscaper{
+		quote:   quote,
+		escapes: map[rune]string{'\\': `\\`, '\n': `\n`, '\t': `\t`, '\r': `\r`},
+		control: pythonEscape,
+		ascii:   pythonEscape,
+	}
+	e.escapes[rune(quote[0])] = `\` + quote
+	return e
+}
+
+// byteLines formats b as a comma separated list of hex bytes, literalBytesPerLine to a line
+func byteLines(b []byte, indent string, format func(b byte) string, trailingComma bool) string {
+	sb := strings.Builder{}
+	for i, c := range b {
+		if i%literalBytesPerLine == 0 {
+			sb.WriteString(indent)
+		}
+		sb.WriteString(format(c))
+		switch {
+		case i == len(b)-1:
+			if trailingComma {
+				sb.WriteString(",")
+			}
+			sb.WriteString("\n")
+		case i%literalBytesPerLine == literalBytesPerLine-1:
+			sb.WriteString(",\n")
+		default:
+			sb.WriteString(", ")
+		}
+	}
+	return sb.String()
+}
+
+func hexByte(b byte) string {
+	return fmt.Sprintf("0x%02x", b)
+}
+
+func encodeBinaryLiteral(lang string, b []byte, name string) (string, error) {
+	switch lang {
+	case "go":
+		if len(b) == 0 {
+			return "[]byte{}", nil
+		}
+		return "[]byte{\n" + byteLines(b, "\t", hexByte, true) + "}", nil
+	case "c":
+		// the same layout as xxd -i
+		return fmt.Sprintf("unsigned char %s[] = {\n%s};\nunsigned int %s_len = %d;", name, byteLines(b, "  ", hexByte, false), name, len(b)), nil
+	case "java":
+		javaByte := func(b byte) string {
+			if b > 0x7f {
+				return "(byte) " + hexByte(b)
+			}
+			return hexByte(b)
+		}
+		return "new byte[] {\n" + byteLines(b, "    ", javaByte, false) + "}", nil
+	case "js":
+		return "new Uint8Array([\n" + byteLines(b, "  ", hexByte, false) + "])", nil
+	case "python":
+		sb := strings.Builder{}
+		sb.WriteString("b'")
+		for _, c := range b {
+			switch {
+			case c == '\'' || c == '\\':
+				sb.WriteString(`\` + string(c))
+			case c == '\n':
+				sb.WriteString(`\n`)
+			case c == '\t':
+				sb.WriteString(`\t`)
+			case c == '\r':
+				sb.WriteString(`\r`)
+			case c < 0x20 || c >= 0x7f:
+				sb.WriteString(fmt.Sprintf("\\x%02x", c))
+			default:
+				sb.WriteByte(c)
+			}
+		}
+		sb.WriteString("'")
+		return sb.String(), nil
+	case "shell":
+		sb := strings.Builder{}
+		sb.WriteString("$'")
+		for _, c := range b {
+			switch {
+			case c == '\'' || c == '\\':
+				sb.WriteString(`\` + string(c))
+			case c < 0x20 || c >= 0x7f:
+				sb.WriteString(fmt.Sprintf("\\x%02x", c))
+			default:
+				sb.WriteByte(c)
+			}
+		}
+		sb.WriteString("'")
+		return sb.String(), nil
+	case "sql":
+		return fmt.Sprintf("X'%X'", b), nil
+	case "json":
+		return "", fmt.Errorf("json strings can not hold binary data, encode it with b64 first")
+	}
+	return "", fmt.Errorf("unknown language %s, expected go, c, python, js, java, json, shell or sql", lang)
+}
+
+func encodeStringLiteral(lang string, s string, raw bool, ascii bool) (string, error) {
+	switch lang {
+	case "go":
+		if raw && canRaw(s, "`") && !strings.Contains(s, "\r") {
+			return "`" + s + "`", nil
+		}
+		if ascii {
+			return strconv.QuoteToASCII(s), nil
+		}
+		return strconv.Quote(s), nil
+	case "c":
+		return cEscaper.escape(s, ascii), nil
+	case "java":
+		return javaEscaper.escape(s, ascii), nil
+	case "js":
+		if raw && canRaw(s, "`\\") && !strings.Contains(s, "${") {
+			return "`" + s + "`", nil
+		}
+		return jsEscaper.escape(s, ascii), nil
+	case "python":
+		e := pythonEscaper(s)
+		if raw && canRaw(s, e.quote+"\n") && !strings.HasSuffix(s, "\\") {
+			return "r" + e.quote + s + e.quote, nil
+		}
+		return e.escape(s, ascii), nil
+	case "shell":
+		plain := !ascii || isASCII(s)
+		for _, r := range s {
+			if r < 0x20 && r != '\n' && r != '\t' || r == 0x7f {
+				plain = false
+			}
+		}
+		if plain {
+			// single quotes keep everything but single quotes as is
+			return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'", nil
+		}
+		return "$" + shellEscaper.escape(s, ascii), nil
+	case "sql":
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'", nil
+	case "json":
+		buf := &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		err := enc.Encode(s)
+		if err != nil {
+			return "", err
+		}
+		res := strings.TrimSuffix(buf.String(), "\n")
+		if ascii {
+			sb := strings.Builder{}
+			for _, r := range res {
+				if r >= 0x80 {
+					sb.WriteString(utf16Escape(r))
+					continue
+				}
+				sb.WriteRune(r)
+			}
+			res = sb.String()
+		}
+		return res, nil
+	}
+	return "", fmt.Errorf("unknown language %s, expected go, c, python, js, java, json, shell or sql", lang)
+}
+
+// canRaw reports if s can be written as a raw string, which has no escapes and can not hold the forbidden characters
+func canRaw(s string, forbidden string) bool {
+	if strings.ContainsAny(s, forbidden) {
+		return false
+	}
+	for _, r := range s {
+		if r != '\n' && r != '\t' && !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func encodeLiteral(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	var res string
+	if c.Bool("bytes") || !utf8.Valid(b) {
+		res, err = encodeBinaryLiteral(c.String("lang"), b, c.String("name"))
+	} else {
+		res, err = encodeStringLiteral(c.String("lang"), string(b), c.Bool("raw"), c.Bool("ascii"))
+	}
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, res)
+	return err
+}
@@ -0,0 +1,70 @@
+package encoders
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestEncodeLiteral(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "go", args: []string{"--lang", "go"}, input: "say \"hi\"\n\x01é", expected: `"say \"hi\"\n\x01é"`},
+		{name: "go ascii", args: []string{"--lang", "go", "--ascii"}, input: "é😀", expected: `"\u00e9\U0001f600"`},
+		{name: "go raw", args: []string{"--lang", "go", "--raw"}, input: "a\\b\n\"c\"", expected: "`a\\b\n\"c\"`"},
+		{name: "go raw falls back to quotes", args: []string{"--lang", "go", "--raw"}, input: "a`b", expected: "\"a`b\""},
+		{name: "go bytes", args: []string{"--lang", "go"}, input: "\x00\xff\x01\x02\x03\x04\x05\x06\x07\x08\x09\x0a\x0b", expected: "[]byte{\n\t0x00, 0xff, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a,\n\t0x0b,\n}"},
+		{name: "c", args: []string{"--lang", "c"}, input: "tab\t\x1b1 é", expected: `"tab\t\0331 é"`},
+		{name: "c ascii", args: []string{"--lang", "c", "--ascii"}, input: "é", expected: `"\303\251"`},
+		{name: "c bytes like xxd -i", args: []string{"--lang", "c", "--name", "blob"}, input: "\x00\xff", expected: "unsigned char blob[] = {\n  0x00, 0xff\n};\nunsigned int blob_len = 2;"},
+		{name: "python", args: []string{"--lang", "python"}, input: "it's\x00", expected: `"it's\x00"`},
+		{name: "python both quotes", args: []string{"--lang", "python", "--ascii"}, input: "'\"é😀", expected: `'\'"\xe9\U0001f600'`},
+		{name: "python raw", args: []string{"--lang", "python", "--raw"}, input: `C:\dir`, expected: `r'C:\dir'`},
+		{name: "python bytes", args: []string{"--lang", "python", "--bytes"}, input: "a'\n\xff", expected: `b'a\'\n\xff'`},
+		{name: "js", args: []string{"--lang", "js", "--ascii"}, input: "\"😀\u2028\x00", expected: `"\"\ud83d\ude00\u2028\x00"`},
+		{name: "js raw", args: []string{"--lang", "js", "--raw"}, input: "line\nline", expected: "`line\nline`"},
+		{name: "js bytes", args: []string{"--lang", "js", "--bytes"}, input: "ab", expected: "new Uint8Array([\n  0x61, 0x62\n])"},
+		{name: "java", args: []string{"--lang", "java", "--ascii"}, input: "\"\x01é", expected: `"\"\001\u00e9"`},
+		{name: "java bytes", args: []string{"--lang", "java", "--bytes"}, input: "\x7f\x80", expected: "new byte[] {\n    0x7f, (byte) 0x80\n}"},
+		{name: "json", args: []string{"--lang", "json"}, input: "<a href=\"x\">\x01", expected: `"<a href=\"x\">\u0001"`},
+		{name: "json binary", args: []string{"--lang", "json"}, input: "\xff", wantErr: true},
+		{name: "shell", args: []string{"--lang", "shell"}, input: "it's $HOME", expected: `'it'\''s $HOME'`},
+		{name: "shell control characters", args: []string{"--lang", "shell"}, input: "it's\x1b[0m\r", expected: `$'it\'s\e[0m\r'`},
+		{name: "sql", args: []string{"--lang", "sql"}, input: "it's", expected: `'it''s'`},
+		{name: "sql bytes", args: []string{"--lang", "sql"}, input: "\x00\xff", expected: `X'00FF'`},
+		{name: "unknown language", args: []string{"--lang", "cobol"}, input: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "lang", Value: "go"},
+					&cli.BoolFlag{Name: "raw"},
+					&cli.BoolFlag{Name: "ascii"},
+					&cli.BoolFlag{Name: "bytes"},
+					&cli.StringFlag{Name: "name", Value: "data"},
+				},
+				Action: encodeLiteral,
+			}
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("encodeLiteral() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && out.String() != tt.expected {
+				t.Errorf("encodeLiteral() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
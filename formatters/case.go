@@ -0,0 +1,180 @@
+package formatters
+
+import (
+	"context"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// caser holds the casing rules of a language, or language neutral rules when none is given
+type caser struct {
+	lower cases.Caser
+	upper cases.Caser
+	title cases.Caser
+}
+
+func newCaser(lang string) (*caser, error) {
+	tag := language.Und
+	if lang != "" {
+		var err error
+		tag, err = language.Parse(lang)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language %s: %w", lang, err)
+		}
+	}
+	return &caser{
+		lower: cases.Lower(tag),
+		upper: cases.Upper(tag),
+		title: cases.Title(tag),
+	}, nil
+}
+
+func caseFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:  "lang",
+			Usage: "BCP 47 language tag for language specific casing, eg. tr, sv, nl or el",
+		},
+	}
+}
+
+// convertCase applies conv to the text read from std in
+func convertCase(conv func(k *caser, s string) string) cli.ActionFunc {
+	return func(ctx context.Context, c *cli.Command) error {
+		in := c.Reader
+		out := c.Writer
+
+		k, err := newCaser(c.String("lang"))
+		if err != nil {
+			return err
+		}
+
+		b, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(out, conv(k, string(b)))
+		return err
+	}
+}
+
+// perLine applies an identifier case to every line, so that a list of names can be converted at once
+func perLine(conv func(k *caser, words []string) string) func(k *caser, s string) string {
+	return func(k *caser, s string) string {
+		lines := strings.Split(s, "\n")
+		for i, line := range lines {
+			trimmed := strings.TrimRight(line, "\r")
+			lines[i] = conv(k, splitWords(trimmed)) + line[len(trimmed):]
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+func joinWords(sep string, each func(i int, w string) string) func(k *caser, words []string) string {
+	return func(k *caser, words []string) string {
+		res := make([]string, len(words))
+		for i, w := range words {
+			res[i] = each(i, w)
+		}
+		return strings.Join(res, sep)
+	}
+}
+
+var (
+	toCamel = perLine(func(k *caser, words []string) string {
+		return joinWords("", func(i int, w string) string {
+			if i == 0 {
+				return k.lower.String(w)
+			}
+			return k.title.String(w)
+		})(k, words)
+	})
+	toPascal = perLine(func(k *caser, words []string) string {
+		return joinWords("", func(i int, w string) string { return k.title.String(w) })(k, words)
+	})
+	toSnake = perLine(func(k *caser, words []string) string {
+		return joinWords("_", func(i int, w string) string { return k.lower.String(w) })(k, words)
+	})
+	toScreamingSnake = perLine(func(k *caser, words []string) string {
+		return joinWords("_", func(i int, w string) string { return k.upper.String(w) })(k, words)
+	})
+	toKebab = perLine(func(k *caser, words []string) string {
+		return joinWords("-", func(i int, w string) string { return k.lower.String(w) })(k, words)
+	})
+)
+
+func toTitle(k *caser, s string) string {
+	return k.title.String(s)
+}
+
+// toSentence lower cases everything but the first letter of each sentence
+func toSentence(k *caser, s string) string {
+	s = k.lower.String(s)
+	buf := &strings.Builder{}
+	start := true
+	for _, r := range s {
+		switch {
+		case start && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			buf.WriteString(k.upper.String(string(r)))
+			start = false
+			continue
+		case r == '.' || r == '!' || r == '?' || r == '\n':
+			start = true
+		}
+		buf.WriteRune(r)
+	}
+	return buf.String()
+}
+
+func toSwapCase(k *caser, s string) string {
+	buf := &strings.Builder{}
+	for _, r := range s {
+		switch {
+		case unicode.IsUpper(r):
+			buf.WriteString(k.lower.String(string(r)))
+		case unicode.IsLower(r):
+			buf.WriteString(k.upper.String(string(r)))
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// splitWords splits an identifier or a phrase into words. Words are separated by anything that is not a letter or
+// a digit, by a change from lower to upper case, and before the last upper case letter of an acronym that is followed
+// by lower case, eg. HTTPServer. Digits stay with the word before them, eg. utf8Decoder is utf8 and Decoder
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsMark(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || ((unicode.IsUpper(prev) || unicode.IsDigit(prev)) && nextLower) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
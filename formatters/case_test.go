@@ -0,0 +1,83 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"camelCase", []string{"camel", "Case"}},
+		{"HTTPServer", []string{"HTTP", "Server"}},
+		{"HTTP2Server", []string{"HTTP2", "Server"}},
+		{"utf8Decoder", []string{"utf8", "Decoder"}},
+		{"snake_case-and kebab", []string{"snake", "case", "and", "kebab"}},
+		{"ID", []string{"ID"}},
+		{"  ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := splitWords(tt.input)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("splitWords() got = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConvertCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   cli.ActionFunc
+		input    string
+		args     []string
+		expected string
+		wantErr  bool
+	}{
+		{name: "camel", action: convertCase(toCamel), input: "XMLHttpRequest\nuser_id\n", expected: "xmlHttpRequest\nuserId\n"},
+		{name: "pascal", action: convertCase(toPascal), input: "parse-json v2", expected: "ParseJsonV2"},
+		{name: "snake", action: convertCase(toSnake), input: "HTTPServer", expected: "http_server"},
+		{name: "screaming snake", action: convertCase(toScreamingSnake), input: "maxRetryCount", expected: "MAX_RETRY_COUNT"},
+		{name: "kebab", action: convertCase(toKebab), input: "Åsa Öberg", expected: "åsa-öberg"},
+		{name: "title", action: convertCase(toTitle), input: "the quick fox", expected: "The Quick Fox"},
+		{name: "title dutch", action: convertCase(toTitle), input: "ijssel", args: []string{"--lang=nl"}, expected: "IJssel"},
+		{name: "sentence", action: convertCase(toSentence), input: "HELLO there. how ARE you?", expected: "Hello there. How are you?"},
+		{name: "swap", action: convertCase(toSwapCase), input: "Hello World", expected: "hELLO wORLD"},
+		{name: "upper turkish", action: toUpperCase, input: "istanbul", args: []string{"--lang=tr"}, expected: "İSTANBUL"},
+		{name: "lower turkish", action: toLowerCase, input: "DIŞ", args: []string{"--lang=tr"}, expected: "dış"},
+		{name: "lower without lang", action: toLowerCase, input: "DIŞ", expected: "diş"},
+		{name: "invalid lang", action: convertCase(toTitle), input: "x", args: []string{"--lang=!!"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := strings.NewReader(tt.input)
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: in,
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "lang"},
+				},
+				Action: tt.action,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("convertCase() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && out.String() != tt.expected {
+				t.Errorf("convertCase() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
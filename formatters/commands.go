@@ -2,6 +2,7 @@ package formatters
 
 import (
 	"github.com/urfave/cli/v3"
+	"golang.org/x/text/unicode/norm"
 )
 
 var Commands = []*cli.Command{
@@ -13,7 +14,16 @@ var Commands = []*cli.Command{
 				Value: 2,
 			},
 			&cli.BoolFlag{
-				Name: "color",
+				Name:  "sort-keys",
+				Usage: "sort object keys",
+			},
+			&cli.BoolFlag{
+				Name:  "compact",
+				Usage: "no whitespace, ignores --indent",
+			},
+			&cli.BoolFlag{
+				Name:  "canonical",
+				Usage: "RFC 8785 canonical json, for hashing and signing. Implies --sort-keys and --compact, never colored",
 			},
 		},
 		Action: formatJSON,
@@ -25,15 +35,252 @@ var Commands = []*cli.Command{
 				Name:  "indent",
 				Value: 2,
 			},
+			&cli.BoolFlag{
+				Name:    "compact",
+				Aliases: []string{"minify"},
+				Usage:   "removes all formatting whitespace",
+			},
+			&cli.BoolFlag{
+				Name:  "wrap-attributes",
+				Usage: "puts each attribute on its own line, for elements with more than one attribute",
+			},
 		},
 		Action: formatXML,
 	},
+	{
+		Name:  "html",
+		Usage: "formats html, indenting block elements and keeping pre, textarea, script and style content as is",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "indent",
+				Value: 2,
+			},
+			&cli.BoolFlag{
+				Name:    "minify",
+				Aliases: []string{"compact"},
+				Usage:   "collapses white space and removes comments",
+			},
+		},
+		Action: formatHTML,
+	},
+	{
+		Name:    "yaml",
+		Aliases: []string{"yml"},
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "indent",
+				Value: 2,
+			},
+			&cli.BoolFlag{
+				Name:  "sort-keys",
+				Usage: "sort mapping keys",
+			},
+			&cli.StringFlag{
+				Name:  "style",
+				Usage: "flow or block, style of mappings and sequences. Defaults to the style of the input",
+			},
+		},
+		Action: formatYAML,
+	},
+	{
+		Name: "toml",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "indent",
+				Usage: "indentation of keys inside tables",
+			},
+			&cli.BoolFlag{
+				Name:  "sort-keys",
+				Usage: "sort keys within each table",
+			},
+			&cli.StringFlag{
+				Name:  "style",
+				Usage: "block puts each array element on its own line, flow keeps arrays on one line unless they hold comments",
+			},
+		},
+		Action: formatTOML,
+	},
+	{
+		Name:   "go",
+		Usage:  "formats go source with go/format, a full file or a list of declarations or statements",
+		Action: formatGoSource,
+	},
+	{
+		Name:  "sql",
+		Usage: "formats sql with uppercase keywords, a clause per line and indented subqueries and case expressions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "indent",
+				Value: 2,
+			},
+			&cli.BoolFlag{
+				Name:    "compact",
+				Aliases: []string{"minify"},
+				Usage:   "collapses the query to one line, keeping line comments on their own lines",
+			},
+			&cli.StringFlag{
+				Name:  "dialect",
+				Value: "postgres",
+				Usage: "quoting rules, postgres or mysql",
+			},
+		},
+		Action: formatSQL,
+	},
+	{
+		Name:  "table",
+		Usage: "renders a list of objects from json, yaml, ndjson or csv as a table",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "style",
+				Value: "box",
+				Usage: "box, plain, markdown or html",
+			},
+			&cli.StringFlag{
+				Name:    "input-format",
+				Aliases: []string{"i"},
+				Usage:   "json, ndjson, yaml or csv, guessed from the input if not given",
+			},
+			&cli.StringSliceFlag{
+				Name:    "columns",
+				Aliases: []string{"c"},
+				Usage:   "columns to show and their order, eg. --columns name,age",
+			},
+			&cli.StringFlag{
+				Name:    "delimiter",
+				Aliases: []string{"d"},
+				Value:   ",",
+				Usage:   "delimiter of csv input",
+			},
+			&cli.IntFlag{
+				Name:  "max-width",
+				Usage: "max width of box and plain tables, defaults to the terminal width. Negative for no limit",
+			},
+			&cli.BoolFlag{
+				Name:  "wrap",
+				Usage: "wrap cells that do not fit instead of truncating them",
+			},
+		},
+		Action: formatTable,
+	},
 	{
 		Name:   "lower",
+		Flags:  caseFlags(),
 		Action: toLowerCase,
 	},
 	{
 		Name:   "upper",
+		Flags:  caseFlags(),
 		Action: toUpperCase,
 	},
+	{
+		Name:   "camel",
+		Usage:  "camelCase, one identifier per line",
+		Flags:  caseFlags(),
+		Action: convertCase(toCamel),
+	},
+	{
+		Name:   "pascal",
+		Usage:  "PascalCase, one identifier per line",
+		Flags:  caseFlags(),
+		Action: convertCase(toPascal),
+	},
+	{
+		Name:   "snake",
+		Usage:  "snake_case, one identifier per line",
+		Flags:  caseFlags(),
+		Action: convertCase(toSnake),
+	},
+	{
+		Name:    "screaming-snake",
+		Aliases: []string{"constant"},
+		Usage:   "SCREAMING_SNAKE_CASE, one identifier per line",
+		Flags:   caseFlags(),
+		Action:  convertCase(toScreamingSnake),
+	},
+	{
+		Name:   "kebab",
+		Usage:  "kebab-case, one identifier per line",
+		Flags:  caseFlags(),
+		Action: convertCase(toKebab),
+	},
+	{
+		Name:   "title",
+		Usage:  "Title Case Of Every Word",
+		Flags:  caseFlags(),
+		Action: convertCase(toTitle),
+	},
+	{
+		Name:   "sentence",
+		Usage:  "Sentence case. Only the first letter of each sentence is upper case",
+		Flags:  caseFlags(),
+		Action: convertCase(toSentence),
+	},
+	{
+		Name:   "swap",
+		Usage:  "sWAP THE CASE of every letter",
+		Flags:  caseFlags(),
+		Action: convertCase(toSwapCase),
+	},
+	{
+		Name:   "nfc",
+		Usage:  "unicode normalization form C, canonical composition",
+		Action: normalize(norm.NFC),
+	},
+	{
+		Name:   "nfd",
+		Usage:  "unicode normalization form D, canonical decomposition",
+		Action: normalize(norm.NFD),
+	},
+	{
+		Name:   "nfkc",
+		Usage:  "unicode normalization form KC, compatibility composition",
+		Action: normalize(norm.NFKC),
+	},
+	{
+		Name:   "nfkd",
+		Usage:  "unicode normalization form KD, compatibility decomposition",
+		Action: normalize(norm.NFKD),
+	},
+	{
+		Name:  "ascii",
+		Usage: "transliterates to ascii, stripping diacritics and mapping ligatures, eg. Ærlig Åsa to AErlig Asa",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "replacement",
+				Value: "?",
+				Usage: "written for characters that have no ascii form",
+			},
+		},
+		Action: formatASCII,
+	},
+	{
+		Name:  "slug",
+		Usage: "url friendly slug, eg. Räksmörgås på menyn to raksmorgas-pa-menyn",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "separator",
+				Value: "-",
+			},
+			&cli.IntFlag{
+				Name:  "max-length",
+				Usage: "max length of the slug, cut at a word boundary when possible",
+			},
+		},
+		Action: formatSlug,
+	},
+	{
+		Name:  "width",
+		Usage: "converts between fullwidth and halfwidth forms of East Asian text",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "full",
+				Usage: "to fullwidth, eg. ABC and ｶﾀｶﾅ to ＡＢＣ and カタカナ",
+			},
+			&cli.BoolFlag{
+				Name:  "half",
+				Usage: "to halfwidth, eg. ＡＢＣ and カタカナ to ABC and ｶﾀｶﾅ",
+			},
+		},
+		Action: formatWidth,
+	},
 }
@@ -3,8 +3,8 @@ package formatters
 import (
 	"bytes"
 	"context"
-	"github.com/go-xmlfmt/xmlfmt"
-	"github.com/hokaccha/go-prettyjson"
+	"fmt"
+	"github.com/crholm/iop/highlight"
 	"github.com/urfave/cli/v3"
 	"io"
 	"strings"
@@ -15,21 +15,31 @@ func formatJSON(ctx context.Context, c *cli.Command) error {
 	in := c.Reader
 	out := c.Writer
 
-	indent := c.Int("indent")
-	color := c.Bool("color")
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
 
-	b, err := io.ReadAll(in)
+	v, err := parseJSON(in)
 	if err != nil {
 		return err
 	}
 
-	f := prettyjson.NewFormatter()
-	f.Indent = int(indent)
-	f.DisabledColor = !color
-	b, err = f.Format(b)
+	w := newJSONWriter(int(c.Int("indent")))
+	w.sortKeys = c.Bool("sort-keys")
+	w.canonical = c.Bool("canonical")
+	if c.Bool("compact") {
+		w.indent = 0
+	}
+
+	b, err := w.format(v)
 	if err != nil {
 		return err
 	}
+	// canonical output is meant for hashing, so it is never colored
+	if !w.canonical {
+		b = []byte(p.JSON(string(b)))
+	}
 	_, err = io.Copy(out, bytes.NewBuffer(b))
 	return err
 }
@@ -47,12 +57,96 @@ func formatXML(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
-	ii := strings.Join(make([]string, indent+1), " ")
+	nodes, err := parseXML(b)
+	if err != nil {
+		return err
+	}
+
+	w := &xmlWriter{
+		indent:    strings.Repeat(" ", int(indent)),
+		compact:   c.Bool("compact"),
+		wrapAttrs: c.Bool("wrap-attributes"),
+	}
+
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(out, p.XML(string(w.format(nodes))))
+
+	return err
+}
+
+func formatYAML(ctx context.Context, c *cli.Command) error {
+
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	err = validateStyle(c.String("style"))
+	if err != nil {
+		return err
+	}
+
+	if c.Int("indent") < 0 {
+		return fmt.Errorf("--indent can not be negative, got %d", c.Int("indent"))
+	}
+
+	f := &yamlFormatter{
+		indent:   int(c.Int("indent")),
+		sortKeys: c.Bool("sort-keys"),
+		style:    c.String("style"),
+	}
+	b, err = f.format(b)
+	if err != nil {
+		return err
+	}
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	b = []byte(p.YAML(string(b)))
+
+	_, err = io.Copy(out, bytes.NewBuffer(b))
+	return err
+}
 
-	buf := xmlfmt.FormatXML(string(b), "", ii)
+func formatTOML(ctx context.Context, c *cli.Command) error {
 
-	_, err = io.Copy(out, bytes.NewBuffer([]byte(buf)))
+	in := c.Reader
+	out := c.Writer
 
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	err = validateStyle(c.String("style"))
+	if err != nil {
+		return err
+	}
+
+	f := &tomlFormatter{
+		indent:   int(c.Int("indent")),
+		sortKeys: c.Bool("sort-keys"),
+		style:    c.String("style"),
+	}
+	b, err = f.format(b)
+	if err != nil {
+		return err
+	}
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	b = []byte(p.TOML(string(b)))
+
+	_, err = io.Copy(out, bytes.NewBuffer(b))
 	return err
 }
 
@@ -67,7 +161,17 @@ func toLowerCase(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
-	_, err = io.Copy(out, bytes.NewBuffer(bytes.ToLower(b)))
+	if c.String("lang") != "" {
+		k, err := newCaser(c.String("lang"))
+		if err != nil {
+			return err
+		}
+		b = []byte(k.lower.String(string(b)))
+	} else {
+		b = bytes.ToLower(b)
+	}
+
+	_, err = io.Copy(out, bytes.NewBuffer(b))
 
 	return err
 }
@@ -83,7 +187,17 @@ func toUpperCase(ctx context.Context, c *cli.Command) error {
 		return err
 	}
 
-	_, err = io.Copy(out, bytes.NewBuffer(bytes.ToUpper(b)))
+	if c.String("lang") != "" {
+		k, err := newCaser(c.String("lang"))
+		if err != nil {
+			return err
+		}
+		b = []byte(k.upper.String(string(b)))
+	} else {
+		b = bytes.ToUpper(b)
+	}
+
+	_, err = io.Copy(out, bytes.NewBuffer(b))
 
 	return err
 }
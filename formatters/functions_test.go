@@ -34,6 +34,30 @@ func TestFormatJSON(t *testing.T) {
 			wantErr:  true,
 			contains: "",
 		},
+		{
+			name:     "trailing garbage",
+			input:    `{"a":1} garbage`,
+			indent:   2,
+			color:    false,
+			wantErr:  true,
+			contains: "",
+		},
+		{
+			name:     "second value",
+			input:    `{"a":1}{"b":2}`,
+			indent:   2,
+			color:    false,
+			wantErr:  true,
+			contains: "",
+		},
+		{
+			name:     "trailing white space",
+			input:    "{\"a\":1}\n \n",
+			indent:   2,
+			color:    false,
+			wantErr:  false,
+			contains: "\"a\": 1",
+		},
 		{
 			name:     "empty json",
 			input:    `{}`,
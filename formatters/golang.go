@@ -0,0 +1,155 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io"
+	"strings"
+)
+
+// Fragments are wrapped in lines of their own, so that comments at the start of a fragment stay in it. Errors are
+// moved up by the number of wrapper lines
+const (
+	goDeclPrefix = "package p\n"
+	goStmtPrefix = "package p\nfunc _() {\n"
+	goStmtSuffix = "\n}\n"
+)
+
+// formatGo formats a Go source file, or a list of declarations or statements when src is not a full file.
+// format.Source handles fragments as well, but does not correct the positions in its errors for the wrapping
+func formatGo(src []byte) ([]byte, error) {
+	_, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+	if err == nil {
+		res, err := format.Source(src)
+		return res, goError(err, src, 0)
+	}
+	if !strings.Contains(err.Error(), "expected 'package'") {
+		return nil, goError(err, src, 0)
+	}
+
+	res, err := format.Source(append([]byte(goDeclPrefix), src...))
+	if err == nil {
+		return stripGoPackage(res), nil
+	}
+	if !strings.Contains(err.Error(), "expected declaration") {
+		return nil, goError(err, src, strings.Count(goDeclPrefix, "\n"))
+	}
+
+	res, err = format.Source([]byte(goStmtPrefix + string(src) + goStmtSuffix))
+	if err != nil {
+		return nil, goError(err, src, strings.Count(goStmtPrefix, "\n"))
+	}
+	return stripGoFunc(res)
+}
+
+// stripGoPackage removes the package clause that formatGo added to declarations
+func stripGoPackage(src []byte) []byte {
+	lines := strings.Split(string(src), "\n")
+	return []byte(strings.Trim(strings.Join(lines[1:], "\n"), "\n") + "\n")
+}
+
+// stripGoFunc returns the body of the function that formatGo wrapped statements in, the lines between its braces
+func stripGoFunc(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Decls) != 1 {
+		return nil, errors.New("statements can not be formatted, they close the function they are wrapped in")
+	}
+	fn, ok := file.Decls[0].(*ast.FuncDecl)
+	if !ok || fn.Body == nil {
+		return nil, errors.New("statements can not be formatted, they close the function they are wrapped in")
+	}
+	first := fset.Position(fn.Body.Lbrace).Line
+	last := fset.Position(fn.Body.Rbrace).Line
+
+	// the body is indented by one level, except for the continuation lines of raw strings
+	raw := rawStringLines(src)
+	lines := strings.Split(string(src), "\n")
+	var body []string
+	for l := first + 1; l < last; l++ {
+		line := lines[l-1]
+		if !raw[l] {
+			line = strings.TrimPrefix(line, "\t")
+		}
+		body = append(body, line)
+	}
+	return []byte(strings.Trim(strings.Join(body, "\n"), "\n") + "\n"), nil
+}
+
+// rawStringLines returns the lines that continue a raw string literal from the line before
+func rawStringLines(src []byte) map[int]bool {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, src, nil, 0)
+
+	lines := map[int]bool{}
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			return lines
+		}
+		if tok == token.STRING && strings.HasPrefix(lit, "`") {
+			start := fset.Position(pos).Line
+			for l := start + 1; l <= start+strings.Count(lit, "\n"); l++ {
+				lines[l] = true
+			}
+		}
+	}
+}
+
+// goError positions parse errors in src, leaving out the wrapper lines before it. Errors in the wrapper after src,
+// like a missing operand, are put at the end of src
+func goError(err error, src []byte, wrapper int) error {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return err
+	}
+	lines := strings.Split(strings.TrimRight(string(src), "\n"), "\n")
+
+	var msgs []string
+	for i, e := range list {
+		if i == 10 {
+			msgs = append(msgs, fmt.Sprintf("and %d more errors", len(list)-i))
+			break
+		}
+		line, col := e.Pos.Line-wrapper, e.Pos.Column
+		switch {
+		case line < 1:
+			line, col = 1, 1
+		case line > len(lines):
+			line, col = len(lines), len(lines[len(lines)-1])+1
+		}
+		msgs = append(msgs, fmt.Sprintf("go error at line %d, column %d: %s", line, col, e.Msg))
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+func formatGoSource(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	b, err = formatGo(b)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, bytes.NewBuffer(b))
+	return err
+}
@@ -0,0 +1,100 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestFormatGo(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "file",
+			input:    "package main\nimport \"fmt\"\nfunc main(){fmt.Println( \"x\")}",
+			expected: "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println(\"x\") }\n",
+		},
+		{
+			name:     "declarations",
+			input:    "type A struct{X int `json:\"x\"`\nLong string}",
+			expected: "type A struct {\n\tX    int `json:\"x\"`\n\tLong string\n}\n",
+		},
+		{
+			name:     "statements keep raw strings",
+			input:    "x:=1\nif x>0{\ny:=`a\n\tb`\n_=y}",
+			expected: "x := 1\nif x > 0 {\n\ty := `a\n\tb`\n\t_ = y\n}\n",
+		},
+		{
+			name:     "statements starting with a comment",
+			input:    "// set x\nx:=1\nif x>0 {\ny:=2\n_=y}",
+			expected: "// set x\nx := 1\nif x > 0 {\n\ty := 2\n\t_ = y\n}\n",
+		},
+		{
+			name:     "declarations starting with a doc comment",
+			input:    "// A is a number\ntype A int\n// B does nothing\nfunc B(){}",
+			expected: "// A is a number\ntype A int\n\n// B does nothing\nfunc B() {}\n",
+		},
+		{
+			name:    "error in file",
+			input:   "package main\nfunc main({}",
+			wantErr: "go error at line 2, column 11",
+		},
+		{
+			name:    "error in statements is positioned in the input",
+			input:   "x := (1\ny := 2",
+			wantErr: "go error at line 1, column 8: expected ')', found newline",
+		},
+		{
+			name:    "error on a later line of statements",
+			input:   "// comment\nx := 1\ny := (2",
+			wantErr: "go error at line 3, column 8",
+		},
+		{
+			name:    "error at the end of statements",
+			input:   "x := ",
+			wantErr: "go error at line 1, column 6: expected operand",
+		},
+		{
+			name:    "statements closing the function they are wrapped in",
+			input:   "x := 1\n}\nfunc y() {",
+			wantErr: "they close the function they are wrapped in",
+		},
+		{
+			name:    "error in declarations",
+			input:   "// doc\ntype A struct{\nX int",
+			wantErr: "go error at line 3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Action: formatGoSource,
+			}
+
+			err := cmd.Run(context.Background(), []string{""})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("formatGoSource() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("formatGoSource() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("formatGoSource() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
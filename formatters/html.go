@@ -0,0 +1,298 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/crholm/iop/highlight"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/net/html"
+	"io"
+	"strings"
+)
+
+var (
+	// htmlBlocks start on their own line and indent their children
+	htmlBlocks = toSet(`address article aside blockquote body canvas caption colgroup dd details dialog div dl dt fieldset
+figcaption figure footer form frameset h1 h2 h3 h4 h5 h6 head header hgroup html iframe legend li main menu nav noscript
+ol optgroup option p picture pre script section select style summary table tbody td template tfoot th thead title tr ul
+video audio object svg math`)
+
+	// htmlVoids have no content and no end tag
+	htmlVoids = toSet(`area base br col embed hr img input link meta param source track wbr`)
+
+	// htmlPreserved keep their content exactly as written
+	htmlPreserved = toSet(`pre textarea script style`)
+
+	// htmlImpliedEnd lists the elements that an opening tag implicitly closes, eg. <li> closes an open <li>
+	htmlImpliedEnd = map[string]map[string]bool{
+		"li":       toSet(`li`),
+		"dt":       toSet(`dt dd`),
+		"dd":       toSet(`dt dd`),
+		"tr":       toSet(`tr td th`),
+		"td":       toSet(`td th`),
+		"th":       toSet(`td th`),
+		"option":   toSet(`option`),
+		"optgroup": toSet(`optgroup option`),
+		"thead":    toSet(`tbody tfoot`),
+		"tbody":    toSet(`thead tbody tfoot tr td th`),
+		"tfoot":    toSet(`thead tbody tr td th`),
+	}
+)
+
+type htmlFrame struct {
+	name      string
+	multiline bool // the element content was broken over lines, its end tag goes on a line of its own
+}
+
+type htmlFormatter struct {
+	indent string
+	minify bool
+
+	buf          bytes.Buffer
+	frames       []*htmlFrame
+	pendingSpace bool // white space was seen between inline content
+	pendingLine  bool // the next inline content starts a new line, eg. after a block element
+}
+
+func (f *htmlFormatter) depth() int {
+	return len(f.frames)
+}
+
+func (f *htmlFormatter) newline() {
+	f.pendingSpace = false
+	f.pendingLine = false
+	if f.minify || f.buf.Len() == 0 {
+		return
+	}
+	f.buf.WriteString("\n")
+	f.buf.WriteString(strings.Repeat(f.indent, f.depth()))
+	if len(f.frames) > 0 {
+		f.frames[len(f.frames)-1].multiline = true
+	}
+}
+
+// inline writes s as part of the flowing content of the current line
+func (f *htmlFormatter) inline(s string) {
+	if f.pendingLine {
+		f.newline()
+	}
+	if f.pendingSpace && f.buf.Len() > 0 {
+		f.buf.WriteString(" ")
+	}
+	f.pendingSpace = false
+	f.buf.WriteString(s)
+}
+
+// block writes s on a line of its own
+func (f *htmlFormatter) block(s string) {
+	f.newline()
+	f.buf.WriteString(s)
+	f.pendingLine = true
+}
+
+// text writes character data with white space collapsed
+func (f *htmlFormatter) text(raw string) {
+	words := strings.Fields(raw)
+	if len(words) == 0 {
+		if raw != "" {
+			f.pendingSpace = true
+		}
+		return
+	}
+	if strings.TrimLeft(raw, " \t\r\n\f") != raw {
+		f.pendingSpace = true
+	}
+	f.inline(strings.Join(words, " "))
+	if strings.TrimRight(raw, " \t\r\n\f") != raw {
+		f.pendingSpace = true
+	}
+}
+
+func (f *htmlFormatter) open(name, tag string) {
+	for len(f.frames) > 0 {
+		top := f.frames[len(f.frames)-1].name
+		if !htmlImpliedEnd[name][top] && !(top == "p" && htmlBlocks[name]) {
+			break
+		}
+		f.close(top, "")
+	}
+
+	switch {
+	case htmlBlocks[name]:
+		f.newline()
+		f.buf.WriteString(tag)
+	case name == "br":
+		f.inline(tag)
+		f.pendingLine = true
+	case htmlVoids[name] && (name == "meta" || name == "link" || name == "hr" || name == "base"):
+		f.block(tag)
+	default:
+		f.inline(tag)
+	}
+	if !htmlVoids[name] {
+		f.frames = append(f.frames, &htmlFrame{name: name})
+	}
+}
+
+// close pops the frames up to and including name. An empty tag closes implicitly, writing nothing
+func (f *htmlFormatter) close(name, tag string) {
+	i := len(f.frames) - 1
+	for i >= 0 && f.frames[i].name != name {
+		i--
+	}
+	if i < 0 {
+		// a stray end tag, write it as is
+		if tag != "" {
+			f.inline(tag)
+		}
+		return
+	}
+	for len(f.frames) > i+1 {
+		f.close(f.frames[len(f.frames)-1].name, "")
+	}
+
+	frame := f.frames[i]
+	f.frames = f.frames[:i]
+	if tag == "" {
+		if htmlBlocks[name] {
+			f.pendingLine = true
+		}
+		return
+	}
+	if !htmlBlocks[name] {
+		f.inline(tag)
+		return
+	}
+	if frame.multiline {
+		f.newline()
+	}
+	f.pendingSpace = false
+	f.buf.WriteString(tag)
+	f.pendingLine = true
+}
+
+// preserve copies the content of a pre, textarea, script or style element, up to and including its end tag
+func (f *htmlFormatter) preserve(z *html.Tokenizer, name string) {
+	nested := 0
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return
+		case html.StartTagToken:
+			if n, _ := z.TagName(); string(n) == name {
+				nested++
+			}
+		case html.EndTagToken:
+			if n, _ := z.TagName(); string(n) == name {
+				if nested == 0 {
+					f.frames = f.frames[:len(f.frames)-1]
+					f.buf.WriteString(normalizeTag(string(z.Raw())))
+					if htmlBlocks[name] {
+						f.pendingLine = true
+					}
+					return
+				}
+				nested--
+			}
+		}
+		f.buf.Write(z.Raw())
+	}
+}
+
+func (f *htmlFormatter) format(src []byte) ([]byte, error) {
+	z := html.NewTokenizer(bytes.NewReader(src))
+	for {
+		tt := z.Next()
+		raw := string(z.Raw())
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() != io.EOF {
+				return nil, fmt.Errorf("html error: %w", z.Err())
+			}
+			return bytes.TrimSpace(f.buf.Bytes()), nil
+		case html.DoctypeToken:
+			f.block(raw)
+		case html.CommentToken:
+			// conditional comments are markup for old browsers and are kept when minifying
+			if f.minify && !strings.HasPrefix(raw, "<!--[if") {
+				continue
+			}
+			f.block(raw)
+		case html.TextToken:
+			f.text(raw)
+		case html.SelfClosingTagToken:
+			n, _ := z.TagName()
+			name := string(n)
+			f.open(name, normalizeTag(raw))
+			if !htmlVoids[name] {
+				// <div/> is not a thing in html, but it is clear what was meant
+				f.frames = f.frames[:len(f.frames)-1]
+			}
+		case html.StartTagToken:
+			n, _ := z.TagName()
+			name := string(n)
+			f.open(name, normalizeTag(raw))
+			if htmlPreserved[name] {
+				f.preserve(z, name)
+			}
+		case html.EndTagToken:
+			n, _ := z.TagName()
+			f.close(string(n), normalizeTag(raw))
+		}
+	}
+}
+
+// normalizeTag collapses the white space of a tag outside of quoted attribute values, keeping the values as written
+func normalizeTag(raw string) string {
+	var sb strings.Builder
+	var quote byte
+	space := false
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			space = true
+			continue
+		}
+		if space && c != '>' && !(c == '/' && i+1 < len(raw) && raw[i+1] == '>') {
+			sb.WriteByte(' ')
+		}
+		space = false
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+func formatHTML(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	f := &htmlFormatter{
+		indent: strings.Repeat(" ", int(c.Int("indent"))),
+		minify: c.Bool("minify"),
+	}
+	res, err := f.format(b)
+	if err != nil {
+		return err
+	}
+
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, p.Format("html", string(res)))
+	return err
+}
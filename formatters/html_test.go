@@ -0,0 +1,73 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestFormatHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+	}{
+		{
+			name:     "blocks are indented",
+			input:    "<!DOCTYPE html><html><head><title>T</title></head><body><div><p>Hi <b>you</b></p></div></body></html>",
+			expected: "<!DOCTYPE html>\n<html>\n  <head>\n    <title>T</title>\n  </head>\n  <body>\n    <div>\n      <p>Hi <b>you</b></p>\n    </div>\n  </body>\n</html>",
+		},
+		{
+			name:     "void elements and unquoted attributes",
+			input:    "<div class=a   id='b'><img src=x.png><br/>text<hr></div>",
+			expected: "<div class=a id='b'><img src=x.png><br/>\n  text\n  <hr>\n</div>",
+		},
+		{
+			name:     "implied end tags",
+			input:    "<ul><li>one<li>two</ul>",
+			expected: "<ul>\n  <li>one\n  <li>two\n</ul>",
+		},
+		{
+			name:     "preserved content",
+			input:    "<div><pre>  a\n <b>b</b> </pre><script>if (a < b) { s = \"</div>\" }</script><textarea> x  y </textarea></div>",
+			expected: "<div>\n  <pre>  a\n <b>b</b> </pre>\n  <script>if (a < b) { s = \"</div>\" }</script>\n  <textarea> x  y </textarea>\n</div>",
+		},
+		{
+			name:     "comments",
+			input:    "<div><!-- note --><p>x</p></div>",
+			expected: "<div>\n  <!-- note -->\n  <p>x</p>\n</div>",
+		},
+		{
+			name:     "minify",
+			args:     []string{"--minify"},
+			input:    "<div>\n  <!-- note -->\n  <p>Hi\n    <b>you</b> </p>\n  <pre> x </pre>\n</div>",
+			expected: "<div><p>Hi <b>you</b></p><pre> x </pre></div>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "indent", Value: 2},
+					&cli.BoolFlag{Name: "minify"},
+				},
+				Action: formatHTML,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil {
+				t.Fatalf("formatHTML() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("formatHTML() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// jsonObject keeps the members of a json object in the order they were read
+type jsonObject []jsonMember
+
+type jsonMember struct {
+	key   string
+	value any
+}
+
+// parseJSON reads a single json value, keeping object key order and the literal representation of numbers
+func parseJSON(r io.Reader) (any, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	v, err := parseJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	offset := dec.InputOffset()
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unexpected data after the json value at offset %d", offset)
+	}
+	return v, nil
+}
+
+func parseJSONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := jsonObject{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := parseJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, jsonMember{key: key.(string), value: v})
+		}
+		_, err = dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			v, err := parseJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		_, err = dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
+
+type jsonWriter struct {
+	indent    int
+	sortKeys  bool
+	canonical bool
+}
+
+func newJSONWriter(indent int) *jsonWriter {
+	return &jsonWriter{
+		indent: indent,
+	}
+}
+
+func (w *jsonWriter) format(v any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := w.write(buf, v, 0)
+	return buf.Bytes(), err
+}
+
+func (w *jsonWriter) newline(buf *bytes.Buffer, depth int) {
+	if w.indent <= 0 || w.canonical {
+		return
+	}
+	buf.WriteByte('\n')
+	buf.WriteString(strings.Repeat(" ", w.indent*depth))
+}
+
+func (w *jsonWriter) write(buf *bytes.Buffer, v any, depth int) error {
+	switch v := v.(type) {
+	case jsonObject:
+		if len(v) == 0 {
+			buf.WriteString("{}")
+			return nil
+		}
+		members := v
+		if w.sortKeys || w.canonical {
+			members = append(jsonObject{}, v...)
+			sort.SliceStable(members, func(i, j int) bool {
+				if w.canonical {
+					return lessUTF16(members[i].key, members[j].key)
+				}
+				return members[i].key < members[j].key
+			})
+		}
+		buf.WriteByte('{')
+		for i, m := range members {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			w.newline(buf, depth+1)
+			buf.WriteString(w.quote(m.key))
+			buf.WriteByte(':')
+			if w.indent > 0 && !w.canonical {
+				buf.WriteByte(' ')
+			}
+			err := w.write(buf, m.value, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+		w.newline(buf, depth)
+		buf.WriteByte('}')
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString("[]")
+			return nil
+		}
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			w.newline(buf, depth+1)
+			err := w.write(buf, e, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+		w.newline(buf, depth)
+		buf.WriteByte(']')
+	case string:
+		buf.WriteString(w.quote(v))
+	case json.Number:
+		s := string(v)
+		if w.canonical {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return fmt.Errorf("number %s can not be represented canonically: %s", s, err)
+			}
+			s, err = es6Number(f)
+			if err != nil {
+				return err
+			}
+		}
+		buf.WriteString(s)
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case nil:
+		buf.WriteString("null")
+	default:
+		return fmt.Errorf("unexpected json value %v", v)
+	}
+	return nil
+}
+
+func (w *jsonWriter) quote(s string) string {
+	if w.canonical {
+		return jcsQuote(s)
+	}
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// jcsQuote escapes a string as required by RFC 8785, only quotes, backslashes and control characters are escaped
+func jcsQuote(s string) string {
+	buf := &strings.Builder{}
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				_, _ = fmt.Fprintf(buf, `\u%04x`, r)
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// lessUTF16 orders strings by their UTF-16 code units, as RFC 8785 requires for object keys
+func lessUTF16(a, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
+
+// es6Number formats f the way ECMAScript's Number.prototype.toString does, as RFC 8785 requires
+func es6Number(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", errors.New("NaN and Infinity are not valid json numbers")
+	}
+	if f == 0 {
+		return "0", nil
+	}
+
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+
+	// shortest round tripping digits, d.ddde±x
+	e := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(e, "e")
+	digits := strings.Replace(mantissa, ".", "", 1)
+	x, err := strconv.Atoi(exp)
+	if err != nil {
+		return "", err
+	}
+	k := len(digits)
+	n := x + 1
+
+	var s string
+	switch {
+	case k <= n && n <= 21:
+		s = digits + strings.Repeat("0", n-k)
+	case 0 < n && n <= 21:
+		s = digits[:n] + "." + digits[n:]
+	case -6 < n && n <= 0:
+		s = "0." + strings.Repeat("0", -n) + digits
+	default:
+		s = digits[:1]
+		if k > 1 {
+			s += "." + digits[1:]
+		}
+		if n-1 >= 0 {
+			s += "e+" + strconv.Itoa(n-1)
+		} else {
+			s += "e-" + strconv.Itoa(-(n - 1))
+		}
+	}
+	return sign + s, nil
+}
@@ -0,0 +1,111 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFormatJSONModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "keeps key order",
+			input:    `{"b":1,"a":{"d":true,"c":null}}`,
+			args:     []string{"--indent=2"},
+			expected: "{\n  \"b\": 1,\n  \"a\": {\n    \"d\": true,\n    \"c\": null\n  }\n}",
+		},
+		{
+			name:     "sort keys",
+			input:    `{"b":1,"a":{"d":true,"c":null}}`,
+			args:     []string{"--indent=1", "--sort-keys"},
+			expected: "{\n \"a\": {\n  \"c\": null,\n  \"d\": true\n },\n \"b\": 1\n}",
+		},
+		{
+			name:     "compact keeps number literals",
+			input:    "{ \"a\" : [ 1.50, 1e3 ], \"b\": \"<&>\" }",
+			args:     []string{"--compact"},
+			expected: `{"a":[1.50,1e3],"b":"<&>"}`,
+		},
+		{
+			name:     "canonical",
+			input:    `{"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001], "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/", "literals": [null, true, false]}`,
+			args:     []string{"--canonical", "--indent=4", "--color"},
+			expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			name:     "canonical utf16 key order",
+			input:    `{"\u20ac":"Euro Sign","\r":"Carriage Return","\ufb33":"Hebrew Letter Dalet With Dagesh","1":"One","\ud83d\ude00":"Emoji: Grinning Face","\u0080":"Control","\u00f6":"Latin Small Letter O With Diaeresis"}`,
+			args:     []string{"--canonical"},
+			expected: "{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Latin Small Letter O With Diaeresis\",\"€\":\"Euro Sign\",\"😀\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := strings.NewReader(tt.input)
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: in,
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "indent"},
+					&cli.BoolFlag{Name: "color"},
+					&cli.BoolFlag{Name: "sort-keys"},
+					&cli.BoolFlag{Name: "compact"},
+					&cli.BoolFlag{Name: "canonical"},
+				},
+				Action: formatJSON,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil {
+				t.Errorf("formatJSON() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("formatJSON() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestES6Number(t *testing.T) {
+	tests := map[float64]string{
+		0:                      "0",
+		math.Copysign(0, -1):   "0",
+		1:                      "1",
+		-1.5:                   "-1.5",
+		1e21:                   "1e+21",
+		1e20:                   "100000000000000000000",
+		123e-20:                "1.23e-18",
+		0.000001:               "0.000001",
+		0.0000001:              "1e-7",
+		9007199254740992:       "9007199254740992",
+		295147905179352830000:  "295147905179352830000",
+		4.35:                   "4.35",
+		5e-324:                 "5e-324",
+		1.7976931348623157e308: "1.7976931348623157e+308",
+	}
+	for in, expected := range tests {
+		got, err := es6Number(in)
+		if err != nil {
+			t.Errorf("es6Number(%v) error = %v", in, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("es6Number(%v) got = %v, want %v", in, got, expected)
+		}
+	}
+
+	if _, err := es6Number(math.NaN()); err == nil {
+		t.Errorf("es6Number(NaN) expected error")
+	}
+}
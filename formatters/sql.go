@@ -0,0 +1,559 @@
+package formatters
+
+import (
+	"context"
+	"fmt"
+	"github.com/urfave/cli/v3"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type sqlKind int
+
+const (
+	sqlWord sqlKind = iota
+	sqlKeyword
+	sqlString // string literals and quoted identifiers, written as is
+	sqlNumber
+	sqlOperator
+	sqlOpen
+	sqlClose
+	sqlComma
+	sqlDot
+	sqlSemicolon
+	sqlLineComment
+	sqlBlockComment
+)
+
+type sqlToken struct {
+	kind sqlKind
+	text string
+}
+
+// sqlDialect holds the quoting rules that differ between databases
+type sqlDialect struct {
+	identQuote       byte // quote of identifiers, " for postgres and ` for mysql
+	doubleQuoteStr   bool // "..." is a string rather than an identifier
+	backslashEscapes bool // backslash escapes the next character in strings
+	dollarQuotes     bool // $tag$...$tag$ strings
+	hashComments     bool // # starts a line comment
+}
+
+var sqlDialects = map[string]sqlDialect{
+	"postgres": {identQuote: '"', dollarQuotes: true},
+	"mysql":    {identQuote: '`', doubleQuoteStr: true, backslashEscapes: true, hashComments: true},
+}
+
+// tokenizeSQL splits src into tokens, dropping white space. Literals and comments keep their exact text
+func tokenizeSQL(src string, d sqlDialect) ([]sqlToken, error) {
+	var tokens []sqlToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		rest := src[i:]
+		r, size := utf8.DecodeRuneInString(rest)
+
+		// quoted returns the length of a literal with the opening quote at start, where a doubled quote is an
+		// escaped quote
+		quoted := func(start int, backslash bool) (int, error) {
+			quote := rest[start]
+			for n := start + 1; n < len(rest); n++ {
+				switch {
+				case backslash && rest[n] == '\\':
+					n++
+				case rest[n] == quote && n+1 < len(rest) && rest[n+1] == quote:
+					n++
+				case rest[n] == quote:
+					return n + 1, nil
+				}
+			}
+			line := strings.Count(src[:i], "\n") + 1
+			return 0, fmt.Errorf("sql error at line %d: unterminated literal starting with %c", line, quote)
+		}
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(rest, "--") || (d.hashComments && c == '#'):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			tokens = append(tokens, sqlToken{sqlLineComment, strings.TrimRight(rest[:end], " \t\r")})
+			i += end
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("sql error at line %d: unterminated comment", strings.Count(src[:i], "\n")+1)
+			}
+			tokens = append(tokens, sqlToken{sqlBlockComment, rest[:end+4]})
+			i += end + 4
+		case c == '\'' || (c == '"' && d.doubleQuoteStr):
+			n, err := quoted(0, d.backslashEscapes)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, sqlToken{sqlString, rest[:n]})
+			i += n
+		case (c == 'E' || c == 'e') && len(rest) > 1 && rest[1] == '\'' && d.dollarQuotes:
+			// postgres escape strings
+			n, err := quoted(1, true)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, sqlToken{sqlString, rest[:n]})
+			i += n
+		case c == d.identQuote:
+			n, err := quoted(0, false)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, sqlToken{sqlString, rest[:n]})
+			i += n
+		case c == '$' && d.dollarQuotes && dollarTag(rest) != "":
+			tag := dollarTag(rest)
+			end := strings.Index(rest[len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("sql error at line %d: unterminated %s string", strings.Count(src[:i], "\n")+1, tag)
+			}
+			n := len(tag) + end + len(tag)
+			tokens = append(tokens, sqlToken{sqlString, rest[:n]})
+			i += n
+		case c >= '0' && c <= '9' || (c == '.' && len(rest) > 1 && rest[1] >= '0' && rest[1] <= '9'):
+			n := 1
+			for n < len(rest) && (isWordByte(rest[n]) || rest[n] == '.' || ((rest[n] == '+' || rest[n] == '-') && (rest[n-1] == 'e' || rest[n-1] == 'E'))) {
+				n++
+			}
+			tokens = append(tokens, sqlToken{sqlNumber, rest[:n]})
+			i += n
+		case unicode.IsLetter(r) || c == '_' || c == '@' || ((c == '$' || c == ':' || c == '?') && len(rest) > 1 && isWordByte(rest[1])):
+			// words, variables and placeholders like $1, :name and @var
+			n := size
+			for n < len(rest) {
+				r, s := utf8.DecodeRuneInString(rest[n:])
+				if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '$' {
+					break
+				}
+				n += s
+			}
+			tokens = append(tokens, sqlToken{sqlWord, rest[:n]})
+			i += n
+		case c == '(':
+			tokens = append(tokens, sqlToken{sqlOpen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, sqlToken{sqlClose, ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, sqlToken{sqlComma, ","})
+			i++
+		case c == ';':
+			tokens = append(tokens, sqlToken{sqlSemicolon, ";"})
+			i++
+		case c == '.':
+			tokens = append(tokens, sqlToken{sqlDot, "."})
+			i++
+		default:
+			n := size
+			for _, op := range []string{"->>", "::", "->", "<=", ">=", "<>", "!=", "||", ":=", "<<", ">>", "#>>", "#>", "@>", "<@"} {
+				if strings.HasPrefix(rest, op) {
+					n = len(op)
+					break
+				}
+			}
+			tokens = append(tokens, sqlToken{sqlOperator, rest[:n]})
+			i += n
+		}
+	}
+	return mergeSQLKeywords(tokens), nil
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// dollarTag returns the opening tag of a postgres dollar quoted string, eg. $$ or $body$
+func dollarTag(s string) string {
+	for n := 1; n < len(s); n++ {
+		if s[n] == '$' {
+			return s[:n+1]
+		}
+		if !isWordByte(s[n]) || (n == 1 && s[n] >= '0' && s[n] <= '9') {
+			return ""
+		}
+	}
+	return ""
+}
+
+var sqlKeywords = toSet(`ADD ALL ALTER AND ANY AS ASC BETWEEN BY CASCADE CASE CAST CHECK COLLATE COLUMN CONFLICT CONSTRAINT
+CREATE CROSS CURRENT_DATE CURRENT_TIME CURRENT_TIMESTAMP DATABASE DEFAULT DELETE DESC DISTINCT DO DROP DUPLICATE ELSE END
+EXCEPT EXISTS FALSE FETCH FILTER FIRST FOR FOREIGN FROM FULL GROUP HAVING IF ILIKE IN INDEX INNER INSERT INTERSECT INTERVAL
+INTO IS JOIN KEY LATERAL LEFT LIKE LIMIT NATURAL NEXT NOT NOTHING NULL NULLS OFFSET ON ONLY OR ORDER OUTER OVER PARTITION
+PRIMARY RECURSIVE REFERENCES REPLACE RETURNING RIGHT ROW ROWS SELECT SET SOME TABLE THEN TO TRUE TRUNCATE UNION UNIQUE
+UPDATE USING VALUES VIEW WHEN WHERE WINDOW WITH`)
+
+func toSet(words string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+// sqlPhrases are keywords that are laid out together, longest first
+var sqlPhrases = [][]string{
+	{"LEFT", "OUTER", "JOIN"}, {"RIGHT", "OUTER", "JOIN"}, {"FULL", "OUTER", "JOIN"},
+	{"ON", "DUPLICATE", "KEY", "UPDATE"},
+	{"GROUP", "BY"}, {"ORDER", "BY"}, {"PARTITION", "BY"}, {"UNION", "ALL"}, {"INSERT", "INTO"}, {"DELETE", "FROM"},
+	{"LEFT", "JOIN"}, {"RIGHT", "JOIN"}, {"FULL", "JOIN"}, {"INNER", "JOIN"}, {"CROSS", "JOIN"}, {"NATURAL", "JOIN"},
+	{"ON", "CONFLICT"}, {"SELECT", "DISTINCT"},
+}
+
+// mergeSQLKeywords marks keywords, joining phrases like GROUP BY into single tokens. Words after a dot are names
+func mergeSQLKeywords(tokens []sqlToken) []sqlToken {
+	var res []sqlToken
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.kind != sqlWord || (i > 0 && tokens[i-1].kind == sqlDot) || (i+1 < len(tokens) && tokens[i+1].kind == sqlDot) {
+			res = append(res, t)
+			continue
+		}
+		upper := strings.ToUpper(t.text)
+		if !sqlKeywords[upper] {
+			res = append(res, t)
+			continue
+		}
+
+		phrase := upper
+		for _, p := range sqlPhrases {
+			if i+len(p) > len(tokens) {
+				continue
+			}
+			match := true
+			for n, w := range p {
+				tok := tokens[i+n]
+				if tok.kind != sqlWord || strings.ToUpper(tok.text) != w {
+					match = false
+					break
+				}
+			}
+			if match {
+				phrase = strings.Join(p, " ")
+				i += len(p) - 1
+				break
+			}
+		}
+		res = append(res, sqlToken{sqlKeyword, phrase})
+	}
+	return res
+}
+
+// clauses start a new line. Block clauses put their content on the lines below, indented one level
+var (
+	sqlBlockClauses = toSet(`SELECT FROM WHERE HAVING SET VALUES RETURNING WINDOW`)
+	sqlLineClauses  = toSet(`LIMIT OFFSET FETCH INSERT UPDATE DELETE WITH`)
+	sqlSetOps       = toSet(`UNION INTERSECT EXCEPT`)
+	sqlJoins        = toSet(`JOIN`)
+)
+
+func sqlClause(kw string) (block bool, line bool, setOp bool, join bool) {
+	switch {
+	case sqlBlockClauses[kw], kw == "GROUP BY", kw == "ORDER BY", kw == "SELECT DISTINCT":
+		return true, false, false, false
+	case sqlLineClauses[kw], kw == "INSERT INTO", kw == "DELETE FROM", kw == "ON CONFLICT", kw == "ON DUPLICATE KEY UPDATE":
+		return false, true, false, false
+	case sqlSetOps[kw], kw == "UNION ALL":
+		return false, false, true, false
+	case sqlJoins[kw], strings.HasSuffix(kw, " JOIN"):
+		return false, false, false, true
+	}
+	return false, false, false, false
+}
+
+type sqlFrameKind int
+
+const (
+	sqlInline sqlFrameKind = iota
+	sqlSubquery
+	sqlCase
+)
+
+type sqlFrame struct {
+	kind  sqlFrameKind
+	base  int  // clause level outside of the frame
+	join  bool // the frame is inside a join condition
+	level int  // line level where a case started
+}
+
+type sqlFormatter struct {
+	indent  string
+	compact bool
+
+	buf      *strings.Builder
+	level    int // level of the current line
+	base     int // level of clause keywords
+	frames   []sqlFrame
+	between  bool // an AND is part of BETWEEN x AND y
+	lineFull bool // a line comment was written, the next token has to start a new line
+	join     bool // the current clause is a join, its AND/OR are indented below it
+	prev2    *sqlToken
+}
+
+func (f *sqlFormatter) format(tokens []sqlToken) string {
+	f.buf = &strings.Builder{}
+	for i, t := range tokens {
+		var prev, next *sqlToken
+		if i > 0 {
+			prev = &tokens[i-1]
+		}
+		if i > 1 {
+			f.prev2 = &tokens[i-2]
+		}
+		if i+1 < len(tokens) {
+			next = &tokens[i+1]
+		}
+		f.write(t, prev, next, tokens[i+1:])
+	}
+	return strings.TrimRight(f.buf.String(), " \n")
+}
+
+func (f *sqlFormatter) top() *sqlFrame {
+	if len(f.frames) == 0 {
+		return nil
+	}
+	return &f.frames[len(f.frames)-1]
+}
+
+// atClauseLevel reports if clause keywords, commas and AND/OR lay out lines, which they do outside of parentheses
+// and inside subqueries
+func (f *sqlFormatter) atClauseLevel() bool {
+	top := f.top()
+	return top == nil || top.kind == sqlSubquery
+}
+
+func (f *sqlFormatter) newline(level int) {
+	if f.compact {
+		return
+	}
+	s := strings.TrimRight(f.buf.String(), " ")
+	f.buf.Reset()
+	f.buf.WriteString(s)
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		f.buf.WriteString("\n")
+	}
+	f.buf.WriteString(strings.Repeat(f.indent, level))
+	f.level = level
+	f.lineFull = false
+}
+
+func (f *sqlFormatter) space(t sqlToken, prev *sqlToken) {
+	if f.lineFull {
+		f.buf.WriteString("\n")
+		f.buf.WriteString(strings.Repeat(f.indent, f.level))
+		f.lineFull = false
+		return
+	}
+	s := f.buf.String()
+	if prev == nil || strings.TrimSpace(s[strings.LastIndex(s, "\n")+1:]) == "" {
+		return
+	}
+	switch {
+	case t.kind == sqlComma || t.kind == sqlClose || t.kind == sqlDot || t.kind == sqlSemicolon:
+		return
+	case prev.kind == sqlOpen || prev.kind == sqlDot || prev.text == "::" || t.text == "::":
+		return
+	case t.kind == sqlOpen && prev.kind == sqlWord && f.prev2 != nil && sqlTableKeywords[f.prev2.text]:
+		// the column list of a table, INSERT INTO t (a, b)
+	case t.kind == sqlOpen && (prev.kind == sqlWord || (prev.kind == sqlKeyword && !sqlSpacedBeforeParen[prev.text] && !strings.Contains(prev.text, " "))):
+		// function calls
+		return
+	}
+	f.buf.WriteString(" ")
+}
+
+// sqlSpacedBeforeParen are keywords that are not function calls when followed by a parenthesis, neither are phrases
+// like ON CONFLICT
+var sqlSpacedBeforeParen = toSet(`AND OR NOT IN EXISTS AS ON USING VALUES FROM JOIN WHERE SELECT INTO TABLE OVER ANY SOME ALL
+WITH THEN ELSE WHEN HAVING SET RETURNING`)
+
+// sqlTableKeywords are followed by a table name and its column list
+var sqlTableKeywords = map[string]bool{"INTO": true, "TABLE": true, "INSERT INTO": true}
+
+func (f *sqlFormatter) write(t sqlToken, prev, next *sqlToken, rest []sqlToken) {
+	if t.kind == sqlKeyword && !f.compact {
+		if f.writeKeyword(t, prev) {
+			return
+		}
+	}
+
+	switch t.kind {
+	case sqlOpen:
+		f.space(t, prev)
+		f.buf.WriteString("(")
+		if startsSubquery(rest) && !f.compact {
+			f.frames = append(f.frames, sqlFrame{kind: sqlSubquery, base: f.base, join: f.join})
+			f.base = f.level + 1
+			f.join = false
+			f.newline(f.base)
+			return
+		}
+		f.frames = append(f.frames, sqlFrame{kind: sqlInline, base: f.base})
+		return
+	case sqlClose:
+		top := f.top()
+		if top != nil && top.kind == sqlCase {
+			// an unterminated case, leave it
+			f.frames = f.frames[:len(f.frames)-1]
+			top = f.top()
+		}
+		if top != nil {
+			f.frames = f.frames[:len(f.frames)-1]
+			if top.kind == sqlSubquery {
+				f.newline(f.base - 1)
+				f.base = top.base
+				f.join = top.join
+			}
+		}
+		f.space(t, prev)
+		f.buf.WriteString(")")
+		return
+	case sqlComma:
+		f.buf.WriteString(",")
+		if f.atClauseLevel() && !f.compact {
+			f.newline(f.base + 1)
+		}
+		return
+	case sqlSemicolon:
+		f.buf.WriteString(";")
+		f.frames = nil
+		f.base = 0
+		f.join = false
+		if !f.compact && next != nil {
+			// statements are separated by an empty line
+			f.buf.WriteString("\n\n")
+			f.level = 0
+		}
+		return
+	case sqlLineComment:
+		f.space(t, prev)
+		f.buf.WriteString(t.text)
+		f.lineFull = true
+		return
+	}
+
+	f.space(t, prev)
+	f.buf.WriteString(t.text)
+}
+
+// writeKeyword lays out clauses, case expressions and boolean operators. It returns false for plain keywords
+func (f *sqlFormatter) writeKeyword(t sqlToken, prev *sqlToken) bool {
+	kw := t.text
+	top := f.top()
+
+	switch kw {
+	case "CASE":
+		f.space(t, prev)
+		f.buf.WriteString(kw)
+		f.frames = append(f.frames, sqlFrame{kind: sqlCase, base: f.base, level: f.level})
+		return true
+	case "WHEN", "ELSE":
+		if top != nil && top.kind == sqlCase {
+			f.newline(top.level + 1)
+			f.buf.WriteString(kw)
+			return true
+		}
+	case "END":
+		if top != nil && top.kind == sqlCase {
+			f.newline(top.level)
+			f.buf.WriteString(kw)
+			f.frames = f.frames[:len(f.frames)-1]
+			return true
+		}
+	case "BETWEEN":
+		f.between = true
+	case "AND", "OR":
+		if kw == "AND" && f.between {
+			f.between = false
+			return false
+		}
+		if f.atClauseLevel() {
+			level := f.base + 1
+			if f.join {
+				level++
+			}
+			f.newline(level)
+			f.buf.WriteString(kw)
+			return true
+		}
+	}
+
+	if !f.atClauseLevel() {
+		return false
+	}
+	block, line, setOp, join := sqlClause(kw)
+	if block || line || setOp || join {
+		f.join = join
+	}
+	switch {
+	case block:
+		f.newline(f.base)
+		f.buf.WriteString(kw)
+		f.newline(f.base + 1)
+	case line:
+		// UPDATE in ON DUPLICATE KEY UPDATE or DO UPDATE is part of the clause before it
+		if kw == "UPDATE" && prev != nil && prev.kind == sqlKeyword && prev.text == "DO" {
+			return false
+		}
+		f.newline(f.base)
+		f.buf.WriteString(kw)
+	case setOp:
+		f.newline(f.base)
+		f.buf.WriteString(kw)
+		f.newline(f.base)
+	case join:
+		f.newline(f.base + 1)
+		f.buf.WriteString(kw)
+	default:
+		return false
+	}
+	return true
+}
+
+// startsSubquery reports if the tokens after an opening parenthesis are a query
+func startsSubquery(rest []sqlToken) bool {
+	for _, t := range rest {
+		if t.kind == sqlLineComment || t.kind == sqlBlockComment {
+			continue
+		}
+		return t.kind == sqlKeyword && (t.text == "SELECT" || t.text == "SELECT DISTINCT" || t.text == "WITH")
+	}
+	return false
+}
+
+func formatSQL(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	dialect, ok := sqlDialects[c.String("dialect")]
+	if !ok {
+		return fmt.Errorf("unknown dialect %s, expected postgres or mysql", c.String("dialect"))
+	}
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := tokenizeSQL(string(b), dialect)
+	if err != nil {
+		return err
+	}
+
+	f := &sqlFormatter{
+		indent:  strings.Repeat(" ", int(c.Int("indent"))),
+		compact: c.Bool("compact"),
+	}
+	_, err = io.WriteString(out, f.format(tokens))
+	return err
+}
@@ -0,0 +1,108 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestFormatSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "clauses",
+			input:    "select a, b from t where x = 1 and y between 1 and 2 order by a limit 5",
+			expected: "SELECT\n  a,\n  b\nFROM\n  t\nWHERE\n  x = 1\n  AND y BETWEEN 1 AND 2\nORDER BY\n  a\nLIMIT 5",
+		},
+		{
+			name:     "joins",
+			input:    "select * from a left outer join b on a.id = b.id and b.ok join c using (id)",
+			expected: "SELECT\n  *\nFROM\n  a\n  LEFT OUTER JOIN b ON a.id = b.id\n    AND b.ok\n  JOIN c USING (id)",
+		},
+		{
+			name:     "subquery",
+			input:    "select id from t where id in (select id from u where n > 0)",
+			expected: "SELECT\n  id\nFROM\n  t\nWHERE\n  id IN (\n    SELECT\n      id\n    FROM\n      u\n    WHERE\n      n > 0\n  )",
+		},
+		{
+			name:     "case",
+			input:    "select case when x > 1 then 'a' else coalesce(y, 'b') end as c from t",
+			expected: "SELECT\n  CASE\n    WHEN x > 1 THEN 'a'\n    ELSE coalesce(y, 'b')\n  END AS c\nFROM\n  t",
+		},
+		{
+			name:     "literals and comments are kept",
+			input:    "SELECT 'select  it''s' AS \"Select\" /* from  x */ -- where\nFROM $$ from $$",
+			expected: "SELECT\n  'select  it''s' AS \"Select\" /* from  x */ -- where\nFROM\n  $$ from $$",
+		},
+		{
+			name:     "statements",
+			input:    "insert into t (a,b) values (1,2),(3,4); delete from t where a=1;",
+			expected: "INSERT INTO t (a, b)\nVALUES\n  (1, 2),\n  (3, 4);\n\nDELETE FROM t\nWHERE\n  a = 1;",
+		},
+		{
+			name:     "compact",
+			args:     []string{"--compact"},
+			input:    "SELECT\n  a::int,\n  count(*)\nFROM\n  t -- all\nWHERE\n  b IN (1, 2)",
+			expected: "SELECT a::int, count(*) FROM t -- all\nWHERE b IN (1, 2)",
+		},
+		{
+			name:     "mysql quoting",
+			args:     []string{"--dialect", "mysql"},
+			input:    "select `from`, \"it\\\"s\" from t # comment",
+			expected: "SELECT\n  `from`,\n  \"it\\\"s\"\nFROM\n  t # comment",
+		},
+		{
+			name:     "postgres escape strings",
+			input:    "select E'it\\'s', \"from\"",
+			expected: "SELECT\n  E'it\\'s',\n  \"from\"",
+		},
+		{
+			name:    "unterminated string",
+			input:   "select 1\nwhere a = 'x",
+			wantErr: "sql error at line 2: unterminated literal",
+		},
+		{
+			name:    "unknown dialect",
+			args:    []string{"--dialect", "oracle"},
+			input:   "select 1",
+			wantErr: "unknown dialect oracle",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "indent", Value: 2},
+					&cli.BoolFlag{Name: "compact"},
+					&cli.StringFlag{Name: "dialect", Value: "postgres"},
+				},
+				Action: formatSQL,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("formatSQL() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("formatSQL() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("formatSQL() got = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
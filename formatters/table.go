@@ -0,0 +1,568 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/crholm/iop/highlight"
+	"github.com/rivo/uniseg"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// table is a list of records with the columns in the order they were first seen
+type table struct {
+	columns []string
+	rows    []map[string]string
+}
+
+func (t *table) add(record jsonObject) {
+	row := map[string]string{}
+	for _, m := range record {
+		if _, ok := row[m.key]; ok {
+			continue
+		}
+		if !t.has(m.key) {
+			t.columns = append(t.columns, m.key)
+		}
+		row[m.key] = cellValue(m.value)
+	}
+	t.rows = append(t.rows, row)
+}
+
+func (t *table) has(column string) bool {
+	for _, c := range t.columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// cellValue is the text of a scalar, or compact json for objects and arrays
+func cellValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case jsonObject, []any:
+		b, err := newJSONWriter(0).format(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
+
+// detectTableFormat guesses the format of the input from its first characters
+func detectTableFormat(b []byte) string {
+	trimmed := bytes.TrimSpace(b)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		return "json"
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return "ndjson"
+	}
+	first, _, _ := bytes.Cut(trimmed, []byte("\n"))
+	if !bytes.Contains(first, []byte(": ")) && !bytes.HasPrefix(first, []byte("- ")) && bytes.ContainsAny(first, ",\t;") {
+		return "csv"
+	}
+	return "yaml"
+}
+
+func parseTable(b []byte, format string, delimiter rune) (*table, error) {
+	t := &table{}
+	addAll := func(v any) error {
+		switch v := v.(type) {
+		case []any:
+			for i, item := range v {
+				obj, ok := item.(jsonObject)
+				if !ok {
+					return fmt.Errorf("expected a list of objects, item %d is a %T", i, item)
+				}
+				t.add(obj)
+			}
+		case jsonObject:
+			t.add(v)
+		default:
+			return fmt.Errorf("expected a list of objects, got %T", v)
+		}
+		return nil
+	}
+
+	switch format {
+	case "json", "ndjson":
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		for dec.More() {
+			v, err := parseJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			err = addAll(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+	case "yaml", "yml":
+		var n yaml.Node
+		err := yaml.Unmarshal(b, &n)
+		if err != nil {
+			return nil, err
+		}
+		if len(n.Content) > 0 {
+			err = addAll(yamlValue(n.Content[0]))
+			if err != nil {
+				return nil, err
+			}
+		}
+	case "csv", "tsv":
+		r := csv.NewReader(bytes.NewReader(b))
+		r.Comma = delimiter
+		if format == "tsv" {
+			r.Comma = '\t'
+		}
+		r.LazyQuotes = true
+		r.FieldsPerRecord = -1
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return t, nil
+		}
+		for _, rec := range records[1:] {
+			obj := jsonObject{}
+			for i, v := range rec {
+				key := strconv.Itoa(i + 1)
+				if i < len(records[0]) {
+					key = records[0][i]
+				}
+				obj = append(obj, jsonMember{key: key, value: v})
+			}
+			t.add(obj)
+		}
+		// columns without any values are still part of the table
+		for _, c := range records[0] {
+			if !t.has(c) {
+				t.columns = append(t.columns, c)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown input format %s, expected json, ndjson, yaml or csv", format)
+	}
+	return t, nil
+}
+
+// yamlValue converts a yaml node into the same ordered values that parseJSON produces
+func yamlValue(n *yaml.Node) any {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return yamlValue(n.Content[0])
+	case yaml.AliasNode:
+		return yamlValue(n.Alias)
+	case yaml.MappingNode:
+		obj := jsonObject{}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			obj = append(obj, jsonMember{key: n.Content[i].Value, value: yamlValue(n.Content[i+1])})
+		}
+		return obj
+	case yaml.SequenceNode:
+		arr := []any{}
+		for _, c := range n.Content {
+			arr = append(arr, yamlValue(c))
+		}
+		return arr
+	}
+	if n.Tag == "!!null" {
+		return nil
+	}
+	return n.Value
+}
+
+// selectColumns picks and orders the columns of the table
+func (t *table) selectColumns(columns []string) error {
+	for _, c := range columns {
+		if !t.has(c) {
+			return fmt.Errorf("unknown column %s, the columns are %s", c, strings.Join(t.columns, ", "))
+		}
+	}
+	t.columns = columns
+	return nil
+}
+
+var numericCell = regexp.MustCompile(`^[-+]?([0-9][0-9_,]*)?(\.[0-9]+)?([eE][-+]?[0-9]+)?%?$`)
+
+// numeric reports if every non empty value of the column is a number, such columns are right aligned
+func (t *table) numeric(column string) bool {
+	seen := false
+	for _, r := range t.rows {
+		v := r[column]
+		if v == "" {
+			continue
+		}
+		if !numericCell.MatchString(v) {
+			return false
+		}
+		seen = true
+	}
+	return seen
+}
+
+type tableRenderer struct {
+	style    string
+	maxWidth int  // 0 for unlimited, only used by box and plain
+	wrap     bool // wrap cells that do not fit instead of truncating them
+	palette  *highlight.Palette
+}
+
+func (r *tableRenderer) render(t *table) (string, error) {
+	switch r.style {
+	case "box", "plain", "":
+		return r.text(t), nil
+	case "markdown", "md":
+		return r.markdown(t), nil
+	case "html":
+		return r.html(t), nil
+	}
+	return "", fmt.Errorf("unknown table style %s, expected box, plain, markdown or html", r.style)
+}
+
+// fitWidths shrinks the widest columns until the table fits within maxWidth, leaving every column at least 3 wide
+func fitWidths(widths []int, overhead int, maxWidth int) []int {
+	if maxWidth <= 0 {
+		return widths
+	}
+	total := overhead
+	for _, w := range widths {
+		total += w
+	}
+	for total > maxWidth {
+		widest := 0
+		for i, w := range widths {
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+		if widths[widest] <= 3 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// oneLine joins the lines of a cell with spaces, as it is shown when not wrapping
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// cellWidth is the width a cell needs to be shown whole, the widest of its lines when wrapping
+func cellWidth(s string, wrap bool) int {
+	if !wrap {
+		return uniseg.StringWidth(oneLine(s))
+	}
+	width := 0
+	for _, line := range strings.Split(s, "\n") {
+		width = max(width, uniseg.StringWidth(line))
+	}
+	return width
+}
+
+// cellLines splits the text of a cell into lines no wider than width, by wrapping or truncating it
+func cellLines(s string, width int, wrap bool) []string {
+	if !wrap {
+		s = oneLine(s)
+		if uniseg.StringWidth(s) <= width {
+			return []string{s}
+		}
+		return []string{truncate(s, width-1) + "…"}
+	}
+
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			// words longer than a line are broken up
+			for uniseg.StringWidth(word) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				head := truncate(word, width)
+				lines = append(lines, head)
+				word = word[len(head):]
+			}
+			switch {
+			case line == "":
+				line = word
+			case uniseg.StringWidth(line)+1+uniseg.StringWidth(word) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// truncate cuts s to at most width display columns, without splitting grapheme clusters
+func truncate(s string, width int) string {
+	res := ""
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		if uniseg.StringWidth(res)+g.Width() > width {
+			break
+		}
+		res += g.Str()
+	}
+	return res
+}
+
+func pad(s string, width int, right bool) string {
+	fill := strings.Repeat(" ", max(width-uniseg.StringWidth(s), 0))
+	if right {
+		return fill + s
+	}
+	return s + fill
+}
+
+func (r *tableRenderer) text(t *table) string {
+	box := r.style != "plain"
+	n := len(t.columns)
+	if n == 0 {
+		return ""
+	}
+
+	widths := make([]int, n)
+	right := make([]bool, n)
+	for i, c := range t.columns {
+		widths[i] = cellWidth(c, r.wrap)
+		right[i] = t.numeric(c)
+		for _, row := range t.rows {
+			widths[i] = max(widths[i], cellWidth(row[c], r.wrap))
+		}
+	}
+	overhead := 2 * (n - 1)
+	if box {
+		overhead = 3*n + 1
+	}
+	widths = fitWidths(widths, overhead, r.maxWidth)
+
+	buf := &strings.Builder{}
+	border := func() {
+		if !box {
+			return
+		}
+		buf.WriteString("+")
+		for _, w := range widths {
+			buf.WriteString(strings.Repeat("-", w+2) + "+")
+		}
+		buf.WriteString("\n")
+	}
+	writeRow := func(cells []string, header bool) {
+		lines := make([][]string, n)
+		height := 1
+		for i, cell := range cells {
+			lines[i] = cellLines(cell, widths[i], r.wrap)
+			height = max(height, len(lines[i]))
+		}
+		for l := 0; l < height; l++ {
+			var parts []string
+			for i := range cells {
+				text := ""
+				if l < len(lines[i]) {
+					text = lines[i][l]
+				}
+				padded := pad(text, widths[i], right[i] && !header)
+				if header {
+					padded = r.palette.Paint(highlight.Header, text) + padded[len(text):]
+				}
+				parts = append(parts, padded)
+			}
+			if box {
+				buf.WriteString("| " + strings.Join(parts, " | ") + " |\n")
+				continue
+			}
+			buf.WriteString(strings.TrimRight(strings.Join(parts, "  "), " ") + "\n")
+		}
+	}
+
+	border()
+	writeRow(t.columns, true)
+	border()
+	for _, row := range t.rows {
+		var cells []string
+		for _, c := range t.columns {
+			cells = append(cells, row[c])
+		}
+		writeRow(cells, false)
+	}
+	if len(t.rows) > 0 {
+		border()
+	}
+	return buf.String()
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func (r *tableRenderer) markdown(t *table) string {
+	if len(t.columns) == 0 {
+		return ""
+	}
+	rows := [][]string{t.columns}
+	for _, row := range t.rows {
+		var cells []string
+		for _, c := range t.columns {
+			cells = append(cells, row[c])
+		}
+		rows = append(rows, cells)
+	}
+
+	widths := make([]int, len(t.columns))
+	for _, row := range rows {
+		for i, cell := range row {
+			row[i] = markdownEscaper.Replace(cell)
+			widths[i] = max(widths[i], uniseg.StringWidth(row[i]), 3)
+		}
+	}
+
+	buf := &strings.Builder{}
+	for n, row := range rows {
+		for i, cell := range row {
+			buf.WriteString("| " + pad(cell, widths[i], n > 0 && t.numeric(t.columns[i])) + " ")
+		}
+		buf.WriteString("|\n")
+		if n == 0 {
+			for i, c := range t.columns {
+				if t.numeric(c) {
+					buf.WriteString("| " + strings.Repeat("-", widths[i]-1) + ": ")
+					continue
+				}
+				buf.WriteString("| " + strings.Repeat("-", widths[i]) + " ")
+			}
+			buf.WriteString("|\n")
+		}
+	}
+	return buf.String()
+}
+
+func (r *tableRenderer) html(t *table) string {
+	buf := &strings.Builder{}
+	buf.WriteString("<table>\n  <thead>\n    <tr>\n")
+	for _, c := range t.columns {
+		buf.WriteString("      <th>" + html.EscapeString(c) + "</th>\n")
+	}
+	buf.WriteString("    </tr>\n  </thead>\n  <tbody>\n")
+	for _, row := range t.rows {
+		buf.WriteString("    <tr>\n")
+		for _, c := range t.columns {
+			cell := strings.ReplaceAll(html.EscapeString(row[c]), "\n", "<br>")
+			if t.numeric(c) {
+				buf.WriteString(`      <td align="right">` + cell + "</td>\n")
+				continue
+			}
+			buf.WriteString("      <td>" + cell + "</td>\n")
+		}
+		buf.WriteString("    </tr>\n")
+	}
+	buf.WriteString("  </tbody>\n</table>\n")
+	return buf.String()
+}
+
+// terminalWidth is the width of std out if it is a terminal, or $COLUMNS, or 0 if unknown
+func terminalWidth(w io.Writer) int {
+	if f, ok := w.(*os.File); ok {
+		if width, _, err := term.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	width, _ := strconv.Atoi(os.Getenv("COLUMNS"))
+	return width
+}
+
+func formatTable(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	format := c.String("input-format")
+	if format == "" {
+		format = detectTableFormat(b)
+	}
+	delimiter := ','
+	switch d := c.String("delimiter"); d {
+	case "\\t":
+		delimiter = '\t'
+	case "":
+	default:
+		delimiter = []rune(d)[0]
+	}
+
+	t, err := parseTable(b, format, delimiter)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", format, err)
+	}
+
+	if columns := c.StringSlice("columns"); len(columns) > 0 {
+		var cols []string
+		for _, col := range columns {
+			for _, part := range strings.Split(col, ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					cols = append(cols, part)
+				}
+			}
+		}
+		err = t.selectColumns(cols)
+		if err != nil {
+			return err
+		}
+	}
+	if len(t.columns) == 0 {
+		return errors.New("no columns found in input")
+	}
+
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+
+	r := &tableRenderer{
+		style:    c.String("style"),
+		maxWidth: int(c.Int("max-width")),
+		wrap:     c.Bool("wrap"),
+		palette:  p,
+	}
+	if r.maxWidth == 0 {
+		r.maxWidth = terminalWidth(out)
+	}
+
+	s, err := r.render(t)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, s)
+	return err
+}
@@ -0,0 +1,145 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestFormatTable(t *testing.T) {
+	people := `[{"name":"Åsa","age":30,"tags":["a"]},{"name":"日本","age":4.5,"note":"x | y"}]`
+	tests := []struct {
+		name     string
+		input    string
+		args     []string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:  "box",
+			input: people,
+			args:  []string{"--style=box"},
+			expected: `+------+-----+-------+-------+
+| name | age | tags  | note  |
++------+-----+-------+-------+
+| Åsa  |  30 | ["a"] |       |
+| 日本 | 4.5 |       | x | y |
++------+-----+-------+-------+
+`,
+		},
+		{
+			name:     "plain with selected columns",
+			input:    people,
+			args:     []string{"--style=plain", "--columns=age,name"},
+			expected: "age  name\n 30  Åsa\n4.5  日本\n",
+		},
+		{
+			name:     "markdown",
+			input:    people,
+			args:     []string{"--style=markdown", "--columns=name,note"},
+			expected: "| name | note   |\n| ---- | ------ |\n| Åsa  |        |\n| 日本 | x \\| y |\n",
+		},
+		{
+			name:     "html",
+			input:    `[{"a":"<b>"}]`,
+			args:     []string{"--style=html"},
+			expected: "<table>\n  <thead>\n    <tr>\n      <th>a</th>\n    </tr>\n  </thead>\n  <tbody>\n    <tr>\n      <td>&lt;b&gt;</td>\n    </tr>\n  </tbody>\n</table>\n",
+		},
+		{
+			name:     "truncate to max width",
+			input:    `[{"a":"one two three four"}]`,
+			args:     []string{"--style=plain", "--max-width=9"},
+			expected: "a\none two …\n",
+		},
+		{
+			name:     "wrap to max width",
+			input:    `[{"a":"one two three four"}]`,
+			args:     []string{"--style=plain", "--max-width=9", "--wrap"},
+			expected: "a\none two\nthree\nfour\n",
+		},
+		{
+			name:     "multi line cell on one line",
+			input:    `[{"name":"a\nbcdef","n":1}]`,
+			args:     []string{"--style=plain"},
+			expected: "name     n\na bcdef  1\n",
+		},
+		{
+			name:     "multi line cell wrapped",
+			input:    `[{"name":"a\nbcdef","n":1}]`,
+			args:     []string{"--style=plain", "--wrap"},
+			expected: "name   n\na      1\nbcdef\n",
+		},
+		{
+			name:     "east asian width is truncated by display width",
+			input:    `[{"a":"日本語です"}]`,
+			args:     []string{"--style=plain", "--max-width=6"},
+			expected: "a\n日本…\n",
+		},
+		{
+			name:     "ndjson",
+			input:    "{\"a\":1}\n{\"b\":2}\n",
+			args:     []string{"--style=plain"},
+			expected: "a  b\n1\n   2\n",
+		},
+		{
+			name:     "yaml",
+			input:    "- b: x\n  a: y\n",
+			args:     []string{"--style=plain"},
+			expected: "b  a\nx  y\n",
+		},
+		{
+			name:     "csv",
+			input:    "a;b\n1;2\n",
+			args:     []string{"--style=plain", "--delimiter=;", "--input-format=csv"},
+			expected: "a  b\n1  2\n",
+		},
+		{
+			name:    "unknown column",
+			input:   people,
+			args:    []string{"--columns=x"},
+			wantErr: "unknown column x",
+		},
+		{
+			name:    "not a list of objects",
+			input:   `[1, 2]`,
+			wantErr: "expected a list of objects",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := strings.NewReader(tt.input)
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: in,
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "style"},
+					&cli.StringFlag{Name: "input-format"},
+					&cli.StringSliceFlag{Name: "columns"},
+					&cli.StringFlag{Name: "delimiter"},
+					&cli.IntFlag{Name: "max-width", Value: -1},
+					&cli.BoolFlag{Name: "wrap"},
+				},
+				Action: formatTable,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("formatTable() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("formatTable() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("formatTable() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
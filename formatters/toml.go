@@ -0,0 +1,314 @@
+package formatters
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/pelletier/go-toml/v2/unstable"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type tomlFormatter struct {
+	indent   int
+	sortKeys bool
+	style    string // block puts array elements on separate lines, arrays with comments always are
+
+	src  []byte
+	base string // indentation of the key value being formatted
+}
+
+// tomlEntry is a top level expression together with the comment lines directly above it
+type tomlEntry struct {
+	comments []string
+	header   bool // a [table] or [[array table]]
+	key      string
+	line     string
+	blank    bool // preceded by an empty line in the input
+}
+
+// format normalizes spacing and quoting of a toml document, keeping comments and key order
+func (f *tomlFormatter) format(src []byte) ([]byte, error) {
+	p := &unstable.Parser{KeepComments: true}
+	p.Reset(src)
+	f.src = src
+	f.base = ""
+
+	var entries []*tomlEntry
+	var comments []string
+	blank := false
+	for p.NextExpression() {
+		e := p.Expression()
+
+		offset, ok := tomlOffset(e)
+		lineBlank := ok && precededByBlankLine(src, offset)
+
+		if e.Kind == unstable.Comment {
+			if len(comments) == 0 {
+				blank = lineBlank
+			}
+			comments = append(comments, string(e.Data))
+			continue
+		}
+		if len(comments) == 0 {
+			blank = lineBlank
+		}
+
+		entry := &tomlEntry{comments: comments, blank: blank}
+		comments = nil
+
+		switch e.Kind {
+		case unstable.Table:
+			f.base = strings.Repeat(" ", f.indent)
+			entry.header = true
+			entry.line = "[" + tomlKey(e.Key()) + "]"
+		case unstable.ArrayTable:
+			f.base = strings.Repeat(" ", f.indent)
+			entry.header = true
+			entry.line = "[[" + tomlKey(e.Key()) + "]]"
+		case unstable.KeyValue:
+			entry.key = tomlKey(e.Key())
+			entry.line = entry.key + " = " + f.value(p, e.Value(), 1)
+		}
+		if c := e.Next(); c != nil && c.Kind == unstable.Comment {
+			entry.line += " " + string(c.Data)
+		}
+		entries = append(entries, entry)
+	}
+	if err := p.Error(); err != nil {
+		var perr *unstable.ParserError
+		if errors.As(err, &perr) && len(perr.Highlight) > 0 {
+			pos := p.Shape(p.Range(perr.Highlight)).Start
+			return nil, fmt.Errorf("toml error at line %d, column %d: %s", pos.Line, pos.Column, perr.Message)
+		}
+		return nil, err
+	}
+
+	if f.sortKeys {
+		entries = sortTOMLEntries(entries)
+	}
+
+	buf := &bytes.Buffer{}
+	inTable := false
+	for i, e := range entries {
+		if i > 0 && (e.header || e.blank) {
+			buf.WriteString("\n")
+		}
+		if e.header {
+			inTable = true
+		}
+		indent := ""
+		if inTable && !e.header {
+			indent = strings.Repeat(" ", f.indent)
+		}
+		for _, c := range e.comments {
+			buf.WriteString(indent + c + "\n")
+		}
+		buf.WriteString(indent + e.line + "\n")
+	}
+	for _, c := range comments {
+		buf.WriteString(c + "\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// sortTOMLEntries sorts the key values of each block, where blocks are separated by table headers and empty lines
+func sortTOMLEntries(entries []*tomlEntry) []*tomlEntry {
+	start := 0
+	for i := 0; i <= len(entries); i++ {
+		if i < len(entries) && !entries[i].header && (i == start || !entries[i].blank) {
+			continue
+		}
+		block := entries[start:i]
+		// the first entry of a block keeps the empty line before it, wherever it ends up
+		blank := len(block) > 0 && block[0].blank
+		sort.SliceStable(block, func(a, b int) bool {
+			return block[a].key < block[b].key
+		})
+		for n, e := range block {
+			e.blank = n == 0 && blank
+		}
+		start = i
+		if i < len(entries) && entries[i].header {
+			start = i + 1
+		}
+	}
+	return entries
+}
+
+func (f *tomlFormatter) value(p *unstable.Parser, v *unstable.Node, depth int) string {
+	switch v.Kind {
+	case unstable.String:
+		raw := string(p.Raw(v.Raw))
+		if strings.HasPrefix(raw, `"""`) || strings.HasPrefix(raw, `'''`) {
+			return raw
+		}
+		return tomlString(string(v.Data))
+	case unstable.Array:
+		type item struct {
+			comments []string
+			value    string
+			trailing string
+		}
+		var items []item
+		var comments []string
+		hasComments := false
+		for it := v.Children(); it.Next(); {
+			n := it.Node()
+			if n.Kind == unstable.Comment {
+				hasComments = true
+				chained := tomlComments(n)
+				// a comment on the same line as the previous element stays behind it
+				if len(items) > 0 && len(comments) == 0 && !startsLine(f.src, int(n.Raw.Offset)) {
+					items[len(items)-1].trailing = chained[0]
+					chained = chained[1:]
+				}
+				comments = append(comments, chained...)
+				continue
+			}
+			items = append(items, item{comments: comments, value: f.value(p, n, depth+1)})
+			comments = nil
+		}
+		if len(items) == 0 && !hasComments {
+			return "[]"
+		}
+
+		// comments can only be kept when the array spans multiple lines
+		if f.style != "block" && !hasComments {
+			var values []string
+			for _, i := range items {
+				values = append(values, i.value)
+			}
+			return "[" + strings.Join(values, ", ") + "]"
+		}
+
+		indent := f.base + strings.Repeat(" ", max(f.indent, 2)*depth)
+		buf := &strings.Builder{}
+		buf.WriteString("[\n")
+		for _, i := range items {
+			for _, c := range i.comments {
+				buf.WriteString(indent + c + "\n")
+			}
+			buf.WriteString(indent + i.value + ",")
+			if i.trailing != "" {
+				buf.WriteString(" " + i.trailing)
+			}
+			buf.WriteString("\n")
+		}
+		for _, c := range comments {
+			buf.WriteString(indent + c + "\n")
+		}
+		buf.WriteString(f.base + strings.Repeat(" ", max(f.indent, 2)*(depth-1)) + "]")
+		return buf.String()
+	case unstable.InlineTable:
+		type member struct{ key, value string }
+		var members []member
+		for it := v.Children(); it.Next(); {
+			kv := it.Node()
+			members = append(members, member{tomlKey(kv.Key()), f.value(p, kv.Value(), depth)})
+		}
+		if len(members) == 0 {
+			return "{}"
+		}
+		if f.sortKeys {
+			sort.SliceStable(members, func(i, j int) bool { return members[i].key < members[j].key })
+		}
+		var parts []string
+		for _, m := range members {
+			parts = append(parts, m.key+" = "+m.value)
+		}
+		return "{ " + strings.Join(parts, ", ") + " }"
+	}
+	return string(v.Data)
+}
+
+// tomlComments returns a comment node and the comments chained to it, which is how comments inside arrays are parsed
+func tomlComments(n *unstable.Node) []string {
+	comments := []string{string(n.Data)}
+	for c := n.Child(); c != nil; c = c.Next() {
+		comments = append(comments, string(c.Data))
+	}
+	return comments
+}
+
+// tomlOffset returns the position in the input where an expression starts
+func tomlOffset(e *unstable.Node) (int, bool) {
+	switch e.Kind {
+	case unstable.Comment:
+		return int(e.Raw.Offset), true
+	case unstable.KeyValue, unstable.Table, unstable.ArrayTable:
+		it := e.Key()
+		if it.Next() {
+			return int(it.Node().Raw.Offset), true
+		}
+	}
+	return 0, false
+}
+
+// startsLine reports if only whitespace is found between the start of the line and offset
+func startsLine(src []byte, offset int) bool {
+	lineStart := bytes.LastIndexByte(src[:offset], '\n')
+	return len(bytes.TrimSpace(src[lineStart+1:offset])) == 0
+}
+
+func precededByBlankLine(src []byte, offset int) bool {
+	lineStart := bytes.LastIndexByte(src[:offset], '\n')
+	if lineStart <= 0 {
+		return false
+	}
+	prevStart := bytes.LastIndexByte(src[:lineStart], '\n')
+	return len(bytes.TrimSpace(src[prevStart+1:lineStart])) == 0
+}
+
+var bareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+func tomlKey(it unstable.Iterator) string {
+	var parts []string
+	for it.Next() {
+		k := string(it.Node().Data)
+		if !bareKey.MatchString(k) {
+			k = tomlString(k)
+		}
+		parts = append(parts, k)
+	}
+	return strings.Join(parts, ".")
+}
+
+// tomlString quotes s as a basic string, or as a literal string if that avoids escaping
+func tomlString(s string) string {
+	needsEscape := strings.ContainsAny(s, "\"\\")
+	hasControl := strings.IndexFunc(s, func(r rune) bool { return r < 0x20 || r == 0x7f }) >= 0
+	if needsEscape && !hasControl && !strings.Contains(s, "'") {
+		return "'" + s + "'"
+	}
+
+	buf := &strings.Builder{}
+	buf.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				_, _ = fmt.Fprintf(buf, `\u%04X`, r)
+				continue
+			}
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+	return buf.String()
+}
@@ -0,0 +1,80 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestFormatTOML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		args     []string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "normalizes spacing and quoting",
+			input:    "# title\ntitle='x'\nb = [1,2,   3]\na.b = {z=1, a=\"q\\\"x\"}\n\n[server]   # srv\nport=80\n",
+			expected: "# title\ntitle = \"x\"\nb = [1, 2, 3]\na.b = { z = 1, a = 'q\"x' }\n\n[server] # srv\nport = 80\n",
+		},
+		{
+			name:     "keeps comments in arrays",
+			input:    "arr = [\n  1, # one\n  # two\n  2,\n]\n",
+			expected: "arr = [\n  1, # one\n  # two\n  2,\n]\n",
+		},
+		{
+			name:     "sort keys within tables",
+			input:    "b = 1\na = 2\n\n[t]\nz = 1\ny = { d = 1, c = 2 }\n",
+			args:     []string{"--sort-keys"},
+			expected: "a = 2\nb = 1\n\n[t]\ny = { c = 2, d = 1 }\nz = 1\n",
+		},
+		{
+			name:     "block style and indent",
+			input:    "[t]\na = [1, 2]\n",
+			args:     []string{"--indent=2", "--style=block"},
+			expected: "[t]\n  a = [\n    1,\n    2,\n  ]\n",
+		},
+		{
+			name:    "positioned errors",
+			input:   "a = 1\nb = [\n",
+			wantErr: "line 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := strings.NewReader(tt.input)
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: in,
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "indent"},
+					&cli.BoolFlag{Name: "sort-keys"},
+					&cli.StringFlag{Name: "style"},
+					&cli.BoolFlag{Name: "color"},
+				},
+				Action: formatTOML,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("formatTOML() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("formatTOML() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("formatTOML() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// normalize writes std in in the given unicode normalization form
+func normalize(form norm.Form) cli.ActionFunc {
+	return func(ctx context.Context, c *cli.Command) error {
+		in := c.Reader
+		out := c.Writer
+
+		_, err := io.Copy(out, transform.NewReader(in, form))
+		return err
+	}
+}
+
+// asciiReplacements are letters and symbols that do not decompose into ascii
+var asciiReplacements = map[rune]string{
+	'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe", 'ß': "ss", 'ẞ': "SS",
+	'Ø': "O", 'ø': "o", 'Đ': "D", 'đ': "d", 'Ð': "D", 'ð': "d", 'Þ': "TH", 'þ': "th",
+	'Ł': "L", 'ł': "l", 'ı': "i", 'Ŋ': "NG", 'ŋ': "ng", 'Ħ': "H", 'ħ': "h", 'ĸ': "q",
+	'‘': "'", '’': "'", '‚': "'", '‛': "'", '“': `"`, '”': `"`, '„': `"`, '«': `"`, '»': `"`,
+	'‐': "-", '‑': "-", '‒': "-", '–': "-", '—': "-", '―': "-", '−': "-",
+	'•': "*", '·': ".", '×': "x", '÷': "/", '€': "EUR", '£': "GBP", '©': "(c)", '®': "(r)", '™': "TM",
+}
+
+// toASCII transliterates s to ascii by decomposing it, dropping the diacritics and mapping ligatures and letters
+// without a decomposition. Anything left is written as replacement
+func toASCII(s string, replacement string) string {
+	buf := &strings.Builder{}
+	for _, r := range norm.NFKD.String(s) {
+		switch {
+		case r <= unicode.MaxASCII:
+			buf.WriteRune(r)
+		case unicode.Is(unicode.Mn, r):
+		case asciiReplacements[r] != "":
+			buf.WriteString(asciiReplacements[r])
+		case unicode.IsSpace(r):
+			buf.WriteByte(' ')
+		default:
+			buf.WriteString(replacement)
+		}
+	}
+	return buf.String()
+}
+
+func formatASCII(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(out, toASCII(string(b), c.String("replacement")))
+	return err
+}
+
+// slugify transliterates s to lower case ascii words joined by sep. With maxLength > 0 the slug is cut at the last
+// word that fits, or mid word if the first word is too long
+func slugify(s string, sep string, maxLength int) string {
+	words := strings.FieldsFunc(strings.ToLower(toASCII(s, "")), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+
+	slug := ""
+	for _, w := range words {
+		next := w
+		if slug != "" {
+			next = slug + sep + w
+		}
+		if maxLength > 0 && len(next) > maxLength {
+			if slug == "" {
+				slug = w[:maxLength]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
+
+func formatSlug(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(out, slugify(string(b), c.String("separator"), int(c.Int("max-length"))))
+	return err
+}
+
+// formatWidth converts between the fullwidth and halfwidth forms used in East Asian text
+func formatWidth(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	var t transform.Transformer
+	switch {
+	case c.Bool("full") && c.Bool("half"):
+		return errors.New("only one of --full and --half can be given")
+	case c.Bool("full"):
+		t = width.Widen
+	case c.Bool("half"):
+		t = width.Narrow
+	default:
+		return errors.New("expected --full or --half")
+	}
+
+	b, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, transform.NewReader(bytes.NewReader(b), t))
+	return err
+}
@@ -0,0 +1,62 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"testing"
+)
+
+func TestUnicodeFormatters(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   cli.ActionFunc
+		input    string
+		args     []string
+		expected string
+		wantErr  bool
+	}{
+		{name: "nfc", action: normalize(norm.NFC), input: "e\u0301", expected: "\u00e9"},
+		{name: "nfd", action: normalize(norm.NFD), input: "\u00e9", expected: "e\u0301"},
+		{name: "nfkc", action: normalize(norm.NFKC), input: "ﬁ ｶ", expected: "fi カ"},
+		{name: "nfkd", action: normalize(norm.NFKD), input: "①", expected: "1"},
+		{name: "ascii", action: formatASCII, input: "Ærlig Åsa “ﬁne” – Straße Łódź", args: []string{"--replacement=?"}, expected: `AErlig Asa "fine" - Strasse Lodz`},
+		{name: "ascii unknown", action: formatASCII, input: "日本 ok", args: []string{"--replacement=_"}, expected: "__ ok"},
+		{name: "slug", action: formatSlug, input: "Räksmörgås på  menyn!", args: []string{"--separator=-"}, expected: "raksmorgas-pa-menyn"},
+		{name: "slug max length", action: formatSlug, input: "Räksmörgås på menyn", args: []string{"--separator=_", "--max-length=15"}, expected: "raksmorgas_pa"},
+		{name: "slug long word", action: formatSlug, input: "Räksmörgås", args: []string{"--separator=-", "--max-length=4"}, expected: "raks"},
+		{name: "full width", action: formatWidth, input: "ABC1ｶﾀｶﾅ", args: []string{"--full"}, expected: "ＡＢＣ１カタカナ"},
+		{name: "half width", action: formatWidth, input: "ＡＢＣ１カタカナ", args: []string{"--half"}, expected: "ABC1ｶﾀｶﾅ"},
+		{name: "width without direction", action: formatWidth, input: "x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := strings.NewReader(tt.input)
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: in,
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "replacement"},
+					&cli.StringFlag{Name: "separator"},
+					&cli.IntFlag{Name: "max-length"},
+					&cli.BoolFlag{Name: "full"},
+					&cli.BoolFlag{Name: "half"},
+				},
+				Action: tt.action,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s error = %v, wantErr %v", tt.name, err, tt.wantErr)
+				return
+			}
+			if err == nil && out.String() != tt.expected {
+				t.Errorf("%s got = %v, want %v", tt.name, out.String(), tt.expected)
+			}
+		})
+	}
+}
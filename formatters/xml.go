@@ -0,0 +1,231 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type xmlKind int
+
+const (
+	xmlElement xmlKind = iota
+	xmlText
+	xmlCDATA
+	xmlOther // comments, processing instructions and directives, written as is
+	xmlPreserved
+)
+
+// xmlNode is a node of the document, keeping the raw source of everything that is not an element
+type xmlNode struct {
+	kind        xmlKind
+	name        string
+	attrs       []xml.Attr
+	selfClosing bool
+	children    []*xmlNode
+	raw         string
+}
+
+// parseXML reads a document into a list of top level nodes. Names keep their namespace prefixes as written
+func parseXML(src []byte) ([]*xmlNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(src))
+
+	errorAt := func(err error) error {
+		line, col := d.InputPos()
+		var syntax *xml.SyntaxError
+		if errors.As(err, &syntax) {
+			err = errors.New(syntax.Msg)
+		}
+		return fmt.Errorf("xml error at line %d, column %d: %s", line, col, err)
+	}
+
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+	preserveDepth := 0
+	var preserveStart int64
+
+	for {
+		start := d.InputOffset()
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errorAt(err)
+		}
+		raw := string(src[start:d.InputOffset()])
+		parent := stack[len(stack)-1]
+
+		if preserveDepth > 0 {
+			switch t := tok.(type) {
+			case xml.StartElement:
+				preserveDepth++
+			case xml.EndElement:
+				preserveDepth--
+				if preserveDepth == 0 {
+					if qualifiedName(t.Name) != parent.name {
+						return nil, errorAt(fmt.Errorf("element <%s> closed by </%s>", parent.name, qualifiedName(t.Name)))
+					}
+					stack = stack[:len(stack)-1]
+					parent.kind = xmlPreserved
+					parent.raw = string(src[preserveStart:d.InputOffset()])
+				}
+			}
+			continue
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{kind: xmlElement, name: qualifiedName(t.Name), attrs: t.Attr, selfClosing: strings.HasSuffix(raw, "/>")}
+			parent.children = append(parent.children, n)
+			// self closing elements are followed by a matching end element from the decoder as well
+			stack = append(stack, n)
+			for _, a := range t.Attr {
+				if a.Name.Space == "xml" && a.Name.Local == "space" && a.Value == "preserve" {
+					preserveDepth = 1
+					preserveStart = start
+				}
+			}
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return nil, errorAt(fmt.Errorf("unexpected end element </%s>", qualifiedName(t.Name)))
+			}
+			if qualifiedName(t.Name) != parent.name {
+				return nil, errorAt(fmt.Errorf("element <%s> closed by </%s>", parent.name, qualifiedName(t.Name)))
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			kind := xmlText
+			if strings.HasPrefix(raw, "<![CDATA[") {
+				kind = xmlCDATA
+			}
+			parent.children = append(parent.children, &xmlNode{kind: kind, raw: raw})
+		default:
+			parent.children = append(parent.children, &xmlNode{kind: xmlOther, raw: raw})
+		}
+	}
+
+	if len(stack) > 1 {
+		return nil, errorAt(fmt.Errorf("element <%s> is never closed", stack[len(stack)-1].name))
+	}
+	return root.children, nil
+}
+
+func qualifiedName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
+
+type xmlWriter struct {
+	indent    string
+	compact   bool
+	wrapAttrs bool
+	buf       *bytes.Buffer
+	started   bool
+}
+
+func (w *xmlWriter) format(nodes []*xmlNode) []byte {
+	w.buf = &bytes.Buffer{}
+	for _, n := range nodes {
+		w.write(n, 0)
+	}
+	return w.buf.Bytes()
+}
+
+// line starts a new line at the given depth, unless compact or at the very beginning of the output
+func (w *xmlWriter) line(depth int) {
+	if w.compact {
+		return
+	}
+	if w.started {
+		w.buf.WriteByte('\n')
+	}
+	w.started = true
+	w.buf.WriteString(strings.Repeat(w.indent, depth))
+}
+
+func (w *xmlWriter) write(n *xmlNode, depth int) {
+	switch n.kind {
+	case xmlText:
+		text := n.raw
+		if !w.compact {
+			text = strings.TrimSpace(text)
+		}
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		w.line(depth)
+		w.buf.WriteString(text)
+	case xmlCDATA, xmlOther, xmlPreserved:
+		w.line(depth)
+		w.buf.WriteString(n.raw)
+	case xmlElement:
+		w.line(depth)
+		w.startTag(n, depth)
+		if n.selfClosing {
+			return
+		}
+
+		children := visibleChildren(n.children)
+		if len(children) == 1 && children[0].kind == xmlText && !strings.Contains(strings.TrimSpace(children[0].raw), "\n") {
+			w.buf.WriteString(strings.TrimSpace(children[0].raw))
+			children = nil
+		}
+		for _, c := range children {
+			w.write(c, depth+1)
+		}
+		if len(children) > 0 {
+			w.line(depth)
+		}
+		w.buf.WriteString("</" + n.name + ">")
+	}
+}
+
+func (w *xmlWriter) startTag(n *xmlNode, depth int) {
+	w.buf.WriteString("<" + n.name)
+	wrap := w.wrapAttrs && !w.compact && len(n.attrs) > 1
+	for _, a := range n.attrs {
+		if wrap {
+			w.buf.WriteByte('\n')
+			w.buf.WriteString(strings.Repeat(w.indent, depth+1))
+		} else {
+			w.buf.WriteByte(' ')
+		}
+		w.buf.WriteString(qualifiedName(a.Name) + `="` + escapeAttr(a.Value) + `"`)
+	}
+	if n.selfClosing {
+		w.buf.WriteString("/>")
+		return
+	}
+	w.buf.WriteString(">")
+}
+
+// visibleChildren drops whitespace only text, which is only there to format the source
+func visibleChildren(nodes []*xmlNode) []*xmlNode {
+	var res []*xmlNode
+	for _, n := range nodes {
+		if n.kind == xmlText && strings.TrimSpace(n.raw) == "" {
+			continue
+		}
+		res = append(res, n)
+	}
+	return res
+}
+
+var attrEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	`"`, "&quot;",
+	"\t", "&#x9;",
+	"\n", "&#xA;",
+	"\r", "&#xD;",
+)
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
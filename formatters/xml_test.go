@@ -0,0 +1,91 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestFormatXMLModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		args     []string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:  "indent",
+			input: `<?xml version="1.0"?><!-- c --><ns:a xmlns:ns="urn:x"><ns:b x="1">t &amp; u</ns:b><c/></ns:a>`,
+			args:  []string{"--indent=2"},
+			expected: `<?xml version="1.0"?>
+<!-- c -->
+<ns:a xmlns:ns="urn:x">
+  <ns:b x="1">t &amp; u</ns:b>
+  <c/>
+</ns:a>`,
+		},
+		{
+			name:     "cdata and preserve are kept as is",
+			input:    "<a><s><![CDATA[ a < b ]]></s><p xml:space=\"preserve\"> x\n  <i>y</i> </p></a>",
+			args:     []string{"--indent=1"},
+			expected: "<a>\n <s>\n  <![CDATA[ a < b ]]>\n </s>\n <p xml:space=\"preserve\"> x\n  <i>y</i> </p>\n</a>",
+		},
+		{
+			name:     "compact",
+			input:    "<a>\n  <b>text</b>\n  <?pi data?>\n</a>\n",
+			args:     []string{"--compact"},
+			expected: "<a><b>text</b><?pi data?></a>",
+		},
+		{
+			name:     "wrap attributes",
+			input:    `<a x="1" y="&quot;2&quot;"><b z="3"/></a>`,
+			args:     []string{"--indent=2", "--wrap-attributes"},
+			expected: "<a\n  x=\"1\"\n  y=\"&quot;2&quot;\">\n  <b z=\"3\"/>\n</a>",
+		},
+		{
+			name:    "mismatched end element",
+			input:   "<a>\n  <b></a>",
+			wantErr: "line 2, column 10",
+		},
+		{
+			name:    "unclosed element",
+			input:   "<a><b></b>",
+			wantErr: "element <a> is never closed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := strings.NewReader(tt.input)
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: in,
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "indent"},
+					&cli.BoolFlag{Name: "compact"},
+					&cli.BoolFlag{Name: "wrap-attributes"},
+				},
+				Action: formatXML,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("formatXML() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("formatXML() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("formatXML() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
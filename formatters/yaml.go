@@ -0,0 +1,98 @@
+package formatters
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"sort"
+	"strings"
+)
+
+// yaml11Words are plain scalars that YAML 1.1 parsers read as booleans or null, they stay quoted when they are strings
+var yaml11Words = map[string]bool{
+	"y": true, "yes": true, "n": true, "no": true, "on": true, "off": true,
+	"true": true, "false": true, "null": true, "~": true,
+}
+
+type yamlFormatter struct {
+	indent   int
+	sortKeys bool
+	style    string // flow, block or empty to keep the style of the input
+}
+
+// format re-encodes every document of src, keeping comments and key order
+func (f *yamlFormatter) format(src []byte) ([]byte, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(src))
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(f.indent)
+
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		f.normalize(&doc)
+		err = enc.Encode(&doc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := enc.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (f *yamlFormatter) normalize(n *yaml.Node) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 {
+			n.Style = 0
+			if n.Tag == "!!str" && yaml11Words[strings.ToLower(n.Value)] {
+				n.Style = yaml.DoubleQuotedStyle
+			}
+		}
+	case yaml.MappingNode, yaml.SequenceNode:
+		switch f.style {
+		case "flow":
+			n.Style |= yaml.FlowStyle
+		case "block":
+			n.Style &^= yaml.FlowStyle
+		}
+	}
+
+	if n.Kind == yaml.MappingNode && f.sortKeys {
+		type pair struct{ key, value *yaml.Node }
+		pairs := make([]pair, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			pairs = append(pairs, pair{n.Content[i], n.Content[i+1]})
+		}
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return pairs[i].key.Value < pairs[j].key.Value
+		})
+		for i, p := range pairs {
+			n.Content[2*i] = p.key
+			n.Content[2*i+1] = p.value
+		}
+	}
+
+	for _, c := range n.Content {
+		f.normalize(c)
+	}
+}
+
+func validateStyle(style string) error {
+	switch style {
+	case "", "flow", "block":
+		return nil
+	}
+	return fmt.Errorf("invalid style, expected flow or block: %s", style)
+}
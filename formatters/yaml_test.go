@@ -0,0 +1,107 @@
+package formatters
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"strings"
+	"testing"
+)
+
+func TestFormatYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		args     []string
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "keeps comments and key order",
+			input:    "# top\nb:   'hello'   # trailing\na:\n    - 1\n    - 2\n",
+			args:     []string{"--indent=2"},
+			expected: "# top\nb: hello # trailing\na:\n  - 1\n  - 2\n",
+		},
+		{
+			name:     "strings read as booleans stay quoted",
+			input:    "a: 'no'\nb: 'yes please'\n",
+			args:     []string{"--indent=2"},
+			expected: "a: \"no\"\nb: yes please\n",
+		},
+		{
+			name:     "sort keys",
+			input:    "b: 1\na:\n  d: 2\n  c: 3\n",
+			args:     []string{"--indent=2", "--sort-keys"},
+			expected: "a:\n  c: 3\n  d: 2\nb: 1\n",
+		},
+		{
+			name:     "block style",
+			input:    "a: [1, {x: y}]\n",
+			args:     []string{"--indent=2", "--style=block"},
+			expected: "a:\n  - 1\n  - x: y\n",
+		},
+		{
+			name:     "flow style",
+			input:    "a:\n  - 1\n  - 2\n",
+			args:     []string{"--indent=2", "--style=flow"},
+			expected: "{a: [1, 2]}\n",
+		},
+		{
+			name:     "multiple documents",
+			input:    "a: 1\n---\nb: 2\n",
+			args:     []string{"--indent=4"},
+			expected: "a: 1\n---\nb: 2\n",
+		},
+		{
+			name:    "invalid style",
+			input:   "a: 1\n",
+			args:    []string{"--style=inline"},
+			wantErr: "invalid style",
+		},
+		{
+			name:    "negative indent",
+			input:   "a: 1\n",
+			args:    []string{"--indent=-1"},
+			wantErr: "--indent can not be negative",
+		},
+		{
+			name:    "invalid yaml",
+			input:   "a: [1, 2\n",
+			args:    []string{"--indent=2"},
+			wantErr: "line",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := strings.NewReader(tt.input)
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: in,
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.IntFlag{Name: "indent"},
+					&cli.BoolFlag{Name: "sort-keys"},
+					&cli.StringFlag{Name: "style"},
+					&cli.BoolFlag{Name: "color"},
+				},
+				Action: formatYAML,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("formatYAML() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("formatYAML() error = %v", err)
+				return
+			}
+			if out.String() != tt.expected {
+				t.Errorf("formatYAML() got = %v, want %v", out.String(), tt.expected)
+			}
+		})
+	}
+}
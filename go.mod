@@ -6,20 +6,17 @@ toolchain go1.23.2
 
 require (
 	github.com/atotto/clipboard v0.1.4
-	github.com/go-xmlfmt/xmlfmt v1.1.3
 	github.com/google/uuid v1.6.0
-	github.com/hokaccha/go-prettyjson v0.0.0-20211117102719-0474bc63780f
+	github.com/mattn/go-isatty v0.0.20
 	github.com/modfin/henry v1.0.1
 	github.com/pelletier/go-toml/v2 v2.2.3
+	github.com/rivo/uniseg v0.4.7
 	github.com/rs/xid v1.6.0
 	github.com/urfave/cli/v3 v3.0.0-beta1
+	golang.org/x/net v0.37.0
+	golang.org/x/term v0.30.0
 	golang.org/x/text v0.23.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/fatih/color v1.18.0 // indirect
-	github.com/mattn/go-colorable v0.1.14 // indirect
-	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.31.0 // indirect
-)
+require golang.org/x/sys v0.31.0 // indirect
@@ -0,0 +1,68 @@
+package highlight
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// CSV colors the header row, the delimiters and numeric or quoted fields of csv data
+func (p *Palette) CSV(src string, comma rune) string {
+	buf := &strings.Builder{}
+	header := true
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case r == comma:
+			buf.WriteString(p.Paint(Punctuation, src[i:i+size]))
+			i += size
+			continue
+		case r == '\n' || r == '\r':
+			if r == '\n' {
+				header = false
+			}
+			buf.WriteRune(r)
+			i++
+			continue
+		}
+
+		// a field, quoted fields may span several lines
+		end := i
+		if src[i] == '"' {
+			end++
+			for end < len(src) {
+				if src[end] == '"' {
+					if end+1 < len(src) && src[end+1] == '"' {
+						end += 2
+						continue
+					}
+					end++
+					break
+				}
+				end++
+			}
+		}
+		for end < len(src) {
+			r, size := utf8.DecodeRuneInString(src[end:])
+			if r == comma || r == '\r' || r == '\n' {
+				break
+			}
+			end += size
+		}
+
+		field := src[i:end]
+		switch trimmed := strings.TrimSpace(field); {
+		case header:
+			buf.WriteString(p.Paint(Header, field))
+		case numberLiteral.MatchString(trimmed):
+			buf.WriteString(p.Paint(Number, field))
+		case trimmed == "true" || trimmed == "false":
+			buf.WriteString(p.Paint(Boolean, field))
+		case strings.HasPrefix(trimmed, `"`):
+			buf.WriteString(p.Paint(String, field))
+		default:
+			buf.WriteString(field)
+		}
+		i = end
+	}
+	return buf.String()
+}
@@ -0,0 +1,82 @@
+package highlight
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	hexdumpOffset = regexp.MustCompile(`^[0-9a-fA-F]{4,}(:|\s)`)
+	hexGroup      = regexp.MustCompile(`^[0-9a-fA-F]{2,}$`)
+)
+
+// Hexdump colors offsets, bytes and the ascii gutter of xxd and hexdump -C style dumps. Zero bytes use the null color
+// and non printable characters in the gutter the comment color
+func (p *Palette) Hexdump(src string) string {
+	lines := strings.Split(src, "\n")
+	for n, line := range lines {
+		buf := &strings.Builder{}
+
+		if m := hexdumpOffset.FindString(line); m != "" {
+			buf.WriteString(p.Paint(Offset, strings.TrimRight(m, " \t")))
+			line = line[len(strings.TrimRight(m, " \t")):]
+		}
+
+		// the gutter is either between | or after the first double space following the bytes
+		hex, gutter, sep := line, "", ""
+		if i := strings.IndexByte(line, '|'); i >= 0 {
+			hex, gutter, sep = line[:i], line[i+1:], "|"
+		} else if i := strings.Index(strings.TrimLeft(line, " "), "  "); i >= 0 {
+			i += len(line) - len(strings.TrimLeft(line, " "))
+			hex, gutter, sep = line[:i], strings.TrimLeft(line[i:], " "), line[i:len(line)-len(strings.TrimLeft(line[i:], " "))]
+		}
+
+		for i := 0; i < len(hex); {
+			end := i
+			for end < len(hex) && hex[end] != ' ' {
+				end++
+			}
+			if end == i {
+				buf.WriteByte(' ')
+				i++
+				continue
+			}
+			group := hex[i:end]
+			switch {
+			case !hexGroup.MatchString(strings.TrimPrefix(strings.TrimSuffix(group, ","), "0x")):
+				buf.WriteString(group)
+			case strings.Trim(strings.TrimPrefix(group, "0x"), "0,") == "":
+				buf.WriteString(p.Paint(Null, group))
+			default:
+				buf.WriteString(p.Paint(Number, group))
+			}
+			i = end
+		}
+
+		if sep != "" {
+			closing := ""
+			if sep == "|" {
+				buf.WriteString(p.Paint(Punctuation, "|"))
+				if strings.HasSuffix(gutter, "|") {
+					gutter, closing = gutter[:len(gutter)-1], "|"
+				}
+			} else {
+				buf.WriteString(sep)
+			}
+			for gutter != "" {
+				role, end := String, strings.IndexByte(gutter, '.')
+				if end == 0 {
+					role, end = Comment, len(gutter)-len(strings.TrimLeft(gutter, "."))
+				}
+				if end < 0 {
+					end = len(gutter)
+				}
+				buf.WriteString(p.Paint(role, gutter[:end]))
+				gutter = gutter[end:]
+			}
+			buf.WriteString(p.Paint(Punctuation, closing))
+		}
+		lines[n] = buf.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,193 @@
+package highlight
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v3"
+	"io"
+	"os"
+	"strings"
+)
+
+// Role is the kind of token being colored, it is also the name used for it in a theme
+type Role string
+
+const (
+	Key         Role = "key"
+	String      Role = "string"
+	Number      Role = "number"
+	Boolean     Role = "boolean"
+	Null        Role = "null"
+	Comment     Role = "comment"
+	Tag         Role = "tag"
+	Attribute   Role = "attribute"
+	Punctuation Role = "punctuation"
+	Header      Role = "header"
+	Offset      Role = "offset"
+)
+
+// Palette colors tokens using a theme, or leaves them as they are when color is turned off
+type Palette struct {
+	enabled bool
+	theme   Theme
+}
+
+// NewPalette creates a palette that always colors using the theme
+func NewPalette(theme Theme) *Palette {
+	return &Palette{enabled: true, theme: theme}
+}
+
+// New creates a palette from the --color and --theme flags of the command or any of its parents.
+// With --color=auto, the default, color is only used when writing to a terminal and NO_COLOR is not set
+func New(c *cli.Command) (*Palette, error) {
+	theme, err := ParseTheme(c.String("theme"))
+	if err != nil {
+		return nil, err
+	}
+
+	mode := "auto"
+	switch v := c.Value("color").(type) {
+	case string:
+		mode = v
+	case bool:
+		if v {
+			mode = "always"
+		}
+	}
+
+	p := &Palette{theme: theme}
+	switch mode {
+	case "always":
+		p.enabled = true
+	case "never":
+		p.enabled = false
+	default:
+		p.enabled = os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb" && isTerminal(c.Writer)
+	}
+	return p, nil
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// Enabled reports if the palette adds any color
+func (p *Palette) Enabled() bool {
+	return p != nil && p.enabled
+}
+
+// Paint wraps s in the escape codes of the role
+func (p *Palette) Paint(r Role, s string) string {
+	if !p.Enabled() || s == "" {
+		return s
+	}
+	sgr := p.theme[r]
+	if sgr == "" {
+		return s
+	}
+	return "\x1b[" + sgr + "m" + s + "\x1b[0m"
+}
+
+// Format highlights src written in the given format, json, yaml, toml, xml, csv, tsv or hexdump
+func (p *Palette) Format(format string, src string) string {
+	if !p.Enabled() {
+		return src
+	}
+	switch format {
+	case "json":
+		return p.JSON(src)
+	case "yaml", "yml":
+		return p.YAML(src)
+	case "toml":
+		return p.TOML(src)
+	case "xml", "html":
+		return p.XML(src)
+	case "csv":
+		return p.CSV(src, ',')
+	case "tsv":
+		return p.CSV(src, '\t')
+	case "hexdump":
+		return p.Hexdump(src)
+	}
+	return src
+}
+
+// Wrap highlights everything the action writes, as the given format. Output is only buffered when color is used
+func Wrap(format string, action cli.ActionFunc) cli.ActionFunc {
+	return func(ctx context.Context, c *cli.Command) error {
+		p, err := New(c)
+		if err != nil {
+			return err
+		}
+		if !p.Enabled() {
+			return action(ctx, c)
+		}
+
+		out := c.Writer
+		buf := &bytes.Buffer{}
+		c.Writer = buf
+		err = action(ctx, c)
+		c.Writer = out
+
+		_, werr := io.WriteString(out, p.Format(format, buf.String()))
+		if err != nil {
+			return err
+		}
+		return werr
+	}
+}
+
+// colorMode is the value of --color. It is a bool flag as well, so that a bare --color means always
+type colorMode string
+
+func (m *colorMode) Set(s string) error {
+	switch strings.ToLower(s) {
+	case "true", "always":
+		*m = "always"
+	case "false", "never":
+		*m = "never"
+	case "auto":
+		*m = "auto"
+	default:
+		return fmt.Errorf("invalid color mode, expected auto, always or never: %s", s)
+	}
+	return nil
+}
+
+func (m *colorMode) String() string {
+	if m == nil || *m == "" {
+		return "auto"
+	}
+	return string(*m)
+}
+
+func (m *colorMode) Get() any {
+	return m.String()
+}
+
+func (m *colorMode) IsBoolFlag() bool {
+	return true
+}
+
+// Flags are the flags controlling color, to be put on the root command so that every sub command inherits them
+func Flags() []cli.Flag {
+	mode := colorMode("auto")
+	return []cli.Flag{
+		&cli.GenericFlag{
+			Name:  "color",
+			Value: &mode,
+			Usage: "auto, always or never. auto colors output when writing to a terminal and NO_COLOR is not set",
+		},
+		&cli.StringFlag{
+			Name:    "theme",
+			Usage:   "color theme, " + strings.Join(ThemeNames(), ", ") + ", optionally followed by overrides, eg. dark:key=35;1:string=32",
+			Sources: cli.EnvVars("IOP_THEME"),
+		},
+	}
+}
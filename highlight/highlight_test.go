@@ -0,0 +1,155 @@
+package highlight
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// testTheme uses the role names as escape codes, so that expectations stay readable
+var testTheme = Theme{
+	Key: "key", String: "str", Number: "num", Boolean: "bool", Null: "null", Comment: "com",
+	Tag: "tag", Attribute: "attr", Header: "head", Offset: "off",
+}
+
+var escape = regexp.MustCompile(`\x1b\[(\w+)m`)
+
+// plain replaces escape codes with <role> markers
+func plain(s string) string {
+	s = strings.ReplaceAll(s, "\x1b[0m", ">")
+	return escape.ReplaceAllString(s, "<$1")
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		input    string
+		expected string
+	}{
+		{
+			name:     "json",
+			format:   "json",
+			input:    `{"k": ["v", 1.5e3, true, null]}`,
+			expected: `{<key"k">: [<str"v">, <num1.5e3>, <booltrue>, <nullnull>]}`,
+		},
+		{
+			name:     "yaml",
+			format:   "yaml",
+			input:    "# c\nk: v # t\nl:\n  - 1\n",
+			expected: "<com# c>\n<keyk>: <strv> <com# t>\n<keyl>:\n  - <num1>\n",
+		},
+		{
+			name:     "toml",
+			format:   "toml",
+			input:    "[t]\nk = 'v'\n",
+			expected: "<key[t]>\n<keyk> = <str'v'>\n",
+		},
+		{
+			name:     "xml",
+			format:   "xml",
+			input:    `<a x="1"><!-- c --><b/>t</a>`,
+			expected: `<tag<a> <attrx>=<str"1"><tag>><com<!-- c -->><tag<b><tag/>>t<tag</a><tag>>`,
+		},
+		{
+			name:     "csv",
+			format:   "csv",
+			input:    "a,b\n1,\"x\ny\"\n",
+			expected: "<heada>,<headb>\n<num1>,<str\"x\ny\">\n",
+		},
+		{
+			name:     "hexdump",
+			format:   "hexdump",
+			input:    "00000000  48 00  |H.|",
+			expected: "<off00000000>  <num48> <null00>  |<strH><com.>|",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := plain(NewPalette(testTheme).Format(tt.format, tt.input))
+			if got != tt.expected {
+				t.Errorf("Format() got = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		role    Role
+		want    string
+		wantErr bool
+	}{
+		{name: "default", spec: "", role: Key, want: "34;1"},
+		{name: "named", spec: "mono", role: String, want: ""},
+		{name: "override", spec: "dark:key=35;1", role: Key, want: "35;1"},
+		{name: "override default", spec: "string=31", role: String, want: "31"},
+		{name: "unknown theme", spec: "nope", wantErr: true},
+		{name: "unknown role", spec: "dark:keys=1", wantErr: true},
+		{name: "invalid sgr", spec: "key=blue", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := ParseTheme(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTheme() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && theme[tt.role] != tt.want {
+				t.Errorf("ParseTheme() got = %v, want %v", theme[tt.role], tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		noColor bool
+		want    bool
+	}{
+		{name: "auto is off when not a terminal", args: nil, want: false},
+		{name: "bare flag", args: []string{"--color"}, want: true},
+		{name: "always", args: []string{"--color=always"}, want: true},
+		{name: "always ignores NO_COLOR", args: []string{"--color=always"}, noColor: true, want: true},
+		{name: "never", args: []string{"--color=never"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.noColor {
+				t.Setenv("NO_COLOR", "1")
+			}
+			var got bool
+			cmd := &cli.Command{
+				Writer: &bytes.Buffer{},
+				Flags:  Flags(),
+				Commands: []*cli.Command{{
+					Name: "sub",
+					Action: func(ctx context.Context, c *cli.Command) error {
+						p, err := New(c)
+						got = p.Enabled()
+						return err
+					},
+				}},
+			}
+
+			err := cmd.Run(context.Background(), append(append([]string{""}, tt.args...), "sub"))
+			if err != nil {
+				t.Errorf("New() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("New() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package highlight
+
+import (
+	"strings"
+)
+
+// JSON colors keys and values of a json document, it works on any formatting and leaves invalid input as is
+func (p *Palette) JSON(src string) string {
+	buf := &strings.Builder{}
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(src))
+
+			// a string followed by : is a key
+			next := end
+			for next < len(src) && strings.IndexByte(" \t\r\n", src[next]) >= 0 {
+				next++
+			}
+			role := String
+			if next < len(src) && src[next] == ':' {
+				role = Key
+			}
+			buf.WriteString(p.Paint(role, src[i:end]))
+			i = end
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(src) && strings.IndexByte("0123456789.eE+-", src[end]) >= 0 {
+				end++
+			}
+			buf.WriteString(p.Paint(Number, src[i:end]))
+			i = end
+		case strings.HasPrefix(src[i:], "true"):
+			buf.WriteString(p.Paint(Boolean, "true"))
+			i += 4
+		case strings.HasPrefix(src[i:], "false"):
+			buf.WriteString(p.Paint(Boolean, "false"))
+			i += 5
+		case strings.HasPrefix(src[i:], "null"):
+			buf.WriteString(p.Paint(Null, "null"))
+			i += 4
+		case strings.IndexByte("{}[]:,", c) >= 0:
+			buf.WriteString(p.Paint(Punctuation, src[i:i+1]))
+			i++
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,113 @@
+package highlight
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Theme maps roles to SGR parameters, eg. "34;1" for bold blue
+type Theme map[Role]string
+
+var themes = map[string]Theme{
+	"default": {
+		Key:       "34;1",
+		String:    "32;1",
+		Number:    "36;1",
+		Boolean:   "33;1",
+		Null:      "30;1",
+		Comment:   "90",
+		Tag:       "34;1",
+		Attribute: "36",
+		Header:    "1;4",
+		Offset:    "90",
+	},
+	"dark": {
+		Key:         "94;1",
+		String:      "92",
+		Number:      "96",
+		Boolean:     "93",
+		Null:        "90;1",
+		Comment:     "90;3",
+		Tag:         "94;1",
+		Attribute:   "96",
+		Punctuation: "37",
+		Header:      "97;1;4",
+		Offset:      "90",
+	},
+	"light": {
+		Key:       "34",
+		String:    "32",
+		Number:    "35",
+		Boolean:   "33",
+		Null:      "90",
+		Comment:   "37;3",
+		Tag:       "34",
+		Attribute: "35",
+		Header:    "1;4",
+		Offset:    "37",
+	},
+	"mono": {
+		Key:     "1",
+		Null:    "2",
+		Comment: "2;3",
+		Tag:     "1",
+		Header:  "1;4",
+		Offset:  "2",
+	},
+}
+
+var sgrParams = regexp.MustCompile(`^[0-9]+(;[0-9]+)*$`)
+
+// ThemeNames returns the names of the built in themes
+func ThemeNames() []string {
+	var names []string
+	for n := range themes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseTheme reads a theme name, optionally followed by : separated role=sgr overrides, eg. dark:key=35;1:comment=.
+// An empty spec is the default theme and an override without a name starts from the default theme
+func ParseTheme(spec string) (Theme, error) {
+	parts := strings.Split(spec, ":")
+	name := "default"
+	if !strings.Contains(parts[0], "=") {
+		if parts[0] != "" {
+			name = parts[0]
+		}
+		parts = parts[1:]
+	}
+
+	base, ok := themes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown theme %s, expected one of %s", name, strings.Join(ThemeNames(), ", "))
+	}
+	theme := Theme{}
+	for r, sgr := range base {
+		theme[r] = sgr
+	}
+
+	for _, p := range parts {
+		role, sgr, ok := strings.Cut(p, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid theme override, expected role=sgr: %s", p)
+		}
+		if _, known := roles[Role(role)]; !known {
+			return nil, fmt.Errorf("unknown role in theme: %s", role)
+		}
+		if sgr != "" && !sgrParams.MatchString(sgr) {
+			return nil, fmt.Errorf("invalid sgr parameters for %s, expected eg. 34;1: %s", role, sgr)
+		}
+		theme[Role(role)] = sgr
+	}
+	return theme, nil
+}
+
+var roles = map[Role]struct{}{
+	Key: {}, String: {}, Number: {}, Boolean: {}, Null: {}, Comment: {},
+	Tag: {}, Attribute: {}, Punctuation: {}, Header: {}, Offset: {},
+}
@@ -0,0 +1,45 @@
+package highlight
+
+import (
+	"strings"
+)
+
+// TOML colors table headers, keys, values and comments of a toml document
+func (p *Palette) TOML(src string) string {
+	lines := strings.Split(src, "\n")
+	var multiline string
+	for n, line := range lines {
+		// continuation of a multi line string
+		if multiline != "" {
+			lines[n] = p.Paint(String, line)
+			if strings.Count(line, multiline)%2 == 1 {
+				multiline = ""
+			}
+			continue
+		}
+
+		body := strings.TrimLeft(line, " \t")
+		indent := line[:len(line)-len(body)]
+		if strings.HasPrefix(body, "#") {
+			lines[n] = p.Paint(Comment, line)
+			continue
+		}
+		if strings.HasPrefix(body, "[") {
+			header, comment := splitComment(body)
+			trimmed := strings.TrimRight(header, " \t")
+			lines[n] = indent + p.Paint(Key, trimmed) + header[len(trimmed):]
+			if comment != "" {
+				lines[n] += p.Paint(Comment, comment)
+			}
+			continue
+		}
+
+		for _, q := range []string{`"""`, `'''`} {
+			if strings.Count(line, q)%2 == 1 {
+				multiline = q
+			}
+		}
+		lines[n] = indent + p.values(body, '=')
+	}
+	return strings.Join(lines, "\n")
+}
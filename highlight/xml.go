@@ -0,0 +1,97 @@
+package highlight
+
+import (
+	"strings"
+)
+
+// XML colors tags, attributes, comments and cdata sections of an xml or html document
+func (p *Palette) XML(src string) string {
+	buf := &strings.Builder{}
+	for i := 0; i < len(src); {
+		rest := src[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			n := until(rest, "-->")
+			buf.WriteString(p.Paint(Comment, rest[:n]))
+			i += n
+		case strings.HasPrefix(rest, "<![CDATA["):
+			n := until(rest, "]]>")
+			buf.WriteString(p.Paint(String, rest[:n]))
+			i += n
+		case strings.HasPrefix(rest, "<?") || strings.HasPrefix(rest, "<!"):
+			n := until(rest, ">")
+			buf.WriteString(p.Paint(Comment, rest[:n]))
+			i += n
+		case strings.HasPrefix(rest, "<"):
+			i += p.tag(buf, rest)
+		default:
+			n := strings.IndexByte(rest, '<')
+			if n < 0 {
+				n = len(rest)
+			}
+			buf.WriteString(rest[:n])
+			i += n
+		}
+	}
+	return buf.String()
+}
+
+// tag colors a start or end tag at the beginning of s and returns its length
+func (p *Palette) tag(buf *strings.Builder, s string) int {
+	i := 1
+	if strings.HasPrefix(s, "</") {
+		i = 2
+	}
+	for i < len(s) && !isSpace(s[i]) && s[i] != '>' && s[i] != '/' {
+		i++
+	}
+	buf.WriteString(p.Paint(Tag, s[:i]))
+
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == '>':
+			buf.WriteString(p.Paint(Tag, ">"))
+			return i + 1
+		case strings.HasPrefix(s[i:], "/>"):
+			buf.WriteString(p.Paint(Tag, "/>"))
+			return i + 2
+		case isSpace(c) || c == '=':
+			buf.WriteByte(c)
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(s[i+1:], c)
+			if end < 0 {
+				end = len(s) - i - 1
+			} else {
+				end += 2
+			}
+			buf.WriteString(p.Paint(String, s[i:i+end]))
+			i += end
+		default:
+			end := i
+			for end < len(s) && !isSpace(s[end]) && strings.IndexByte("=>/", s[end]) < 0 {
+				end++
+			}
+			if end == i {
+				end++
+			}
+			buf.WriteString(p.Paint(Attribute, s[i:end]))
+			i = end
+		}
+	}
+	return i
+}
+
+// until returns the length of s up to and including end, or all of s if end is missing
+func until(s string, end string) int {
+	n := strings.Index(s, end)
+	if n < 0 {
+		return len(s)
+	}
+	return n + len(end)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,153 @@
+package highlight
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	numberLiteral = regexp.MustCompile(`^[-+]?(0x[0-9a-fA-F_]+|0o[0-7_]+|0b[01_]+|[0-9][0-9_]*(\.[0-9_]*)?([eE][-+]?[0-9_]+)?|\.[0-9]+([eE][-+]?[0-9]+)?|inf|nan|\.inf|\.nan)$`)
+	dateLiteral   = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}(:\d{2}(\.\d+)?)?([Zz]|[-+]\d{2}:\d{2})?)?$|^\d{2}:\d{2}:\d{2}(\.\d+)?$`)
+	yamlKeyPrefix = regexp.MustCompile(`^("(?:[^"\\]|\\.)*"|'(?:[^']|'')*'|[^\s#'"\-\[\]{},][^#]*?|-[^\s#][^#]*?)(:)(\s|$)`)
+)
+
+// scalar colors a single plain value, eg. a number, a boolean or a string
+func (p *Palette) scalar(s string) string {
+	trimmed := strings.TrimSpace(s)
+	switch {
+	case trimmed == "":
+		return s
+	case strings.HasPrefix(trimmed, `"`) || strings.HasPrefix(trimmed, `'`):
+		return p.Paint(String, s)
+	case trimmed == "true" || trimmed == "false":
+		return p.Paint(Boolean, s)
+	case trimmed == "null" || trimmed == "~":
+		return p.Paint(Null, s)
+	case numberLiteral.MatchString(trimmed) || dateLiteral.MatchString(trimmed):
+		return p.Paint(Number, s)
+	}
+	return p.Paint(String, s)
+}
+
+// values colors the values of a flow style collection or a plain value, leaving brackets and separators as they are.
+// Words followed by sep, = for toml and : for yaml, are colored as keys
+func (p *Palette) values(s string, sep byte) string {
+	buf := &strings.Builder{}
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '#':
+			buf.WriteString(p.Paint(Comment, s[i:]))
+			return buf.String()
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(s) && s[end] != c {
+				if c == '"' && s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end < len(s) {
+				end++
+			}
+			buf.WriteString(p.Paint(String, s[i:end]))
+			i = end
+		case strings.ContainsRune("[]{}, \t", rune(c)) || c == sep:
+			buf.WriteByte(c)
+			i++
+		default:
+			end := i
+			isSep := func(i int) bool {
+				return s[i] == sep && (sep != ':' || i+1 == len(s) || s[i+1] == ' ')
+			}
+			for end < len(s) && !strings.ContainsRune("[]{},#", rune(s[end])) && !isSep(end) {
+				end++
+			}
+			word := s[i:end]
+			trimmed := strings.TrimRight(word, " \t")
+			if end < len(s) && isSep(end) {
+				buf.WriteString(p.Paint(Key, trimmed))
+			} else {
+				buf.WriteString(p.scalar(trimmed))
+			}
+			buf.WriteString(word[len(trimmed):])
+			i = end
+		}
+	}
+	return buf.String()
+}
+
+// splitComment splits a line at the first # that starts a comment, ignoring any inside quotes
+func splitComment(line string) (string, string) {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i], line[i:]
+		}
+	}
+	return line, ""
+}
+
+// YAML colors keys, scalars and comments of a yaml document
+func (p *Palette) YAML(src string) string {
+	lines := strings.Split(src, "\n")
+	blockIndent := -1
+	for n, line := range lines {
+		body := strings.TrimLeft(line, " ")
+		indent := len(line) - len(body)
+
+		// content of a literal or folded block scalar
+		if blockIndent >= 0 {
+			if body == "" || indent > blockIndent {
+				lines[n] = p.Paint(String, line)
+				continue
+			}
+			blockIndent = -1
+		}
+
+		if strings.HasPrefix(body, "#") {
+			lines[n] = p.Paint(Comment, line)
+			continue
+		}
+		if body == "---" || body == "..." {
+			continue
+		}
+
+		buf := &strings.Builder{}
+		buf.WriteString(line[:indent])
+		for strings.HasPrefix(body, "- ") || body == "-" {
+			buf.WriteString(body[:min(2, len(body))])
+			body = body[min(2, len(body)):]
+		}
+
+		value, comment := splitComment(body)
+		if m := yamlKeyPrefix.FindStringSubmatch(value); m != nil {
+			buf.WriteString(p.Paint(Key, m[1]))
+			buf.WriteString(m[2] + m[3])
+			value = value[len(m[0]):]
+		}
+
+		trimmed := strings.TrimSpace(value)
+		if strings.HasPrefix(trimmed, "|") || strings.HasPrefix(trimmed, ">") {
+			blockIndent = indent
+			buf.WriteString(value)
+		} else {
+			buf.WriteString(p.values(value, ':'))
+		}
+		if comment != "" {
+			buf.WriteString(p.Paint(Comment, comment))
+		}
+		lines[n] = buf.String()
+	}
+	return strings.Join(lines, "\n")
+}
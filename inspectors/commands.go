@@ -0,0 +1,19 @@
+package inspectors
+
+import (
+	"github.com/urfave/cli/v3"
+)
+
+var DescribeCommand = &cli.Command{
+	Name:      "describe",
+	Usage:     "lists the grapheme clusters and codepoints of a string, flagging invisible, confusable and bidi control characters",
+	ArgsUsage: "[string], read from std in if not given",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "format",
+			Value: "text",
+			Usage: "output format, [text | json | yaml | toml | xml ]",
+		},
+	},
+	Action: describe,
+}
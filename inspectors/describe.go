@@ -0,0 +1,252 @@
+package inspectors
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/crholm/iop/highlight"
+	"github.com/crholm/iop/utils"
+	"github.com/rivo/uniseg"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/unicode/runenames"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type description struct {
+	XMLName    xml.Name   `json:"-" yaml:"-" toml:"-" xml:"description"`
+	Bytes      int        `json:"bytes" yaml:"bytes" toml:"bytes" xml:"bytes"`
+	Codepoints int        `json:"codepoints" yaml:"codepoints" toml:"codepoints" xml:"codepoints"`
+	Width      int        `json:"width" yaml:"width" toml:"width" xml:"width"`
+	Graphemes  []grapheme `json:"graphemes" yaml:"graphemes" toml:"graphemes" xml:"grapheme"`
+}
+
+type grapheme struct {
+	Offset     int         `json:"offset" yaml:"offset" toml:"offset" xml:"offset,attr"`
+	Text       string      `json:"text" yaml:"text" toml:"text" xml:"text"`
+	Width      int         `json:"width" yaml:"width" toml:"width" xml:"width"`
+	Codepoints []codepoint `json:"codepoints" yaml:"codepoints" toml:"codepoints" xml:"codepoint"`
+}
+
+type codepoint struct {
+	Codepoint  string   `json:"codepoint" yaml:"codepoint" toml:"codepoint" xml:"value,attr"`
+	Name       string   `json:"name" yaml:"name" toml:"name" xml:"name"`
+	Category   string   `json:"category" yaml:"category" toml:"category" xml:"category"`
+	UTF8       string   `json:"utf8" yaml:"utf8" toml:"utf8" xml:"utf8"`
+	Width      int      `json:"width" yaml:"width" toml:"width" xml:"width"`
+	Flags      []string `json:"flags,omitempty" yaml:"flags,omitempty" toml:"flags,omitempty" xml:"flag,omitempty"`
+	Confusable string   `json:"confusable_with,omitempty" yaml:"confusable_with,omitempty" toml:"confusable_with,omitempty" xml:"confusable_with,omitempty"`
+}
+
+func describe(ctx context.Context, c *cli.Command) error {
+	in := c.Reader
+	out := c.Writer
+
+	var s string
+	if c.Args().Len() > 0 {
+		s = strings.Join(c.Args().Slice(), " ")
+	} else {
+		b, err := io.ReadAll(in)
+		if err != nil {
+			return err
+		}
+		s = string(b)
+	}
+
+	d := describeString(s)
+
+	format := c.String("format")
+	if format == "text" || format == "txt" || format == "" {
+		_, err := io.WriteString(out, d.text())
+		return err
+	}
+
+	b, err := utils.Marshaller(format)(d)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %s", format, err)
+	}
+	p, err := highlight.New(c)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, p.Format(format, string(b)))
+	return err
+}
+
+func describeString(s string) description {
+	d := description{
+		Bytes:      len(s),
+		Codepoints: utf8.RuneCountInString(s),
+		Width:      uniseg.StringWidth(s),
+		Graphemes:  []grapheme{},
+	}
+
+	offset := 0
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		text := g.Str()
+		gr := grapheme{Offset: offset, Text: text, Width: g.Width()}
+		for i := 0; i < len(text); {
+			r, size := utf8.DecodeRuneInString(text[i:])
+			gr.Codepoints = append(gr.Codepoints, describeRune(r, text[i:i+size]))
+			i += size
+		}
+		d.Graphemes = append(d.Graphemes, gr)
+		offset += len(text)
+	}
+	return d
+}
+
+func describeRune(r rune, raw string) codepoint {
+	cp := codepoint{
+		Codepoint: fmt.Sprintf("U+%04X", r),
+		Name:      runenames.Name(r),
+		Category:  category(r),
+		UTF8:      strings.ToUpper(fmt.Sprintf("% x", raw)),
+		Width:     uniseg.StringWidth(string(r)),
+	}
+	if r == utf8.RuneError && raw != string(utf8.RuneError) {
+		cp.Codepoint = "invalid"
+		cp.Name = "INVALID UTF-8"
+		cp.Category = ""
+		cp.Width = 0
+		cp.Flags = append(cp.Flags, "invalid-utf8")
+	}
+	if cp.Name == "" {
+		cp.Name = "<unassigned>"
+	}
+
+	if invisible(r) {
+		cp.Flags = append(cp.Flags, "invisible")
+	}
+	if unicode.Is(unicode.Bidi_Control, r) {
+		cp.Flags = append(cp.Flags, "bidi-control")
+	}
+	if like := confusable(r); like != "" {
+		cp.Flags = append(cp.Flags, "confusable")
+		cp.Confusable = like
+	}
+	return cp
+}
+
+// categoryNames are the two letter general categories, eg. Lu and Mn. LC is left out since it is the union of Lu, Ll and Lt
+var categoryNames = func() []string {
+	var names []string
+	for n := range unicode.Categories {
+		if len(n) == 2 && n != "LC" {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}()
+
+func category(r rune) string {
+	for _, n := range categoryNames {
+		if unicode.Is(unicode.Categories[n], r) {
+			return n
+		}
+	}
+	return "Cn"
+}
+
+// invisible reports characters that take up no visible space, other than ordinary white space
+func invisible(r rune) bool {
+	switch {
+	case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+		return false
+	case unicode.Is(unicode.Cf, r), unicode.Is(unicode.Cc, r), unicode.Is(unicode.Zs, r),
+		unicode.Is(unicode.Zl, r), unicode.Is(unicode.Zp, r), unicode.Is(unicode.Variation_Selector, r):
+		return true
+	case r == '͏' || r == 'ᅟ' || r == 'ᅠ' || r == 'ㅤ' || r == 'ﾠ':
+		// combining grapheme joiner and the hangul fillers
+		return true
+	}
+	return false
+}
+
+// confusables are letters commonly mistaken for latin ones, a subset of the Unicode confusables data. Characters whose
+// compatibility form is ascii, eg. fullwidth and mathematical letters, are found through NFKC
+var confusables = map[rune]string{
+	// cyrillic
+	'а': "a", 'в': "B", 'е': "e", 'һ': "h", 'і': "i", 'ј': "j", 'к': "k", 'м': "M", 'н': "H", 'о': "o", 'р': "p",
+	'с': "c", 'т': "T", 'у': "y", 'х': "x", 'ѕ': "s", 'ԁ': "d", 'ԛ': "q", 'ԝ': "w", 'ӏ': "l", 'ɡ': "g",
+	'А': "A", 'В': "B", 'Е': "E", 'К': "K", 'М': "M", 'Н': "H", 'О': "O", 'Р': "P", 'С': "C", 'Т': "T",
+	'Х': "X", 'Ѕ': "S", 'І': "I", 'Ј': "J", 'Ԁ': "D", 'Ү': "Y", 'Ԛ': "Q", 'Ԝ': "W",
+	// greek
+	'α': "a", 'ο': "o", 'ν': "v", 'ρ': "p", 'ι': "i", 'κ': "k", 'τ': "t", 'υ': "u",
+	'Α': "A", 'Β': "B", 'Ε': "E", 'Ζ': "Z", 'Η': "H", 'Ι': "I", 'Κ': "K", 'Μ': "M", 'Ν': "N", 'Ο': "O",
+	'Ρ': "P", 'Τ': "T", 'Υ': "Y", 'Χ': "X",
+	// latin and punctuation look alikes
+	'ı': "i", 'ǀ': "l", 'ℓ': "l", 'ß': "B", 'ø': "o", '‐': "-", '‑': "-", '–': "-", '—': "-", '−': "-",
+	'‘': "'", '’': "'", '‚': ",", '“': `"`, '”': `"`, '′': "'", '″': `"`, '⁄': "/", '∕': "/", '꞉': ":", 'ː': ":",
+	'；': ";", 'ꓲ': "I", 'Ꭺ': "A", 'Ᏼ': "B", 'Ꮯ': "C", 'Ꭼ': "E", 'Ꮋ': "H", 'Ꮶ': "K", 'Ꮇ': "M", 'Ꮲ': "P", 'Ꭲ': "T",
+}
+
+// confusable returns the ascii that r can be mistaken for, if any
+func confusable(r rune) string {
+	if r < utf8.RuneSelf {
+		return ""
+	}
+	if like, ok := confusables[r]; ok {
+		return like
+	}
+	if k := norm.NFKC.String(string(r)); k != string(r) && isPrintableASCII(k) {
+		return k
+	}
+	return ""
+}
+
+func isPrintableASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x21 || s[i] > 0x7e {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// text renders the description as an aligned table, one row per codepoint
+func (d description) text() string {
+	rows := [][]string{{"offset", "grapheme", "width", "codepoint", "category", "utf-8", "name"}}
+	for _, g := range d.Graphemes {
+		for i, cp := range g.Codepoints {
+			row := []string{"", "", "", cp.Codepoint, cp.Category, cp.UTF8, cp.Name}
+			if i == 0 {
+				row[0], row[1], row[2] = strconv.Itoa(g.Offset), strconv.Quote(g.Text), strconv.Itoa(g.Width)
+			}
+			for _, f := range cp.Flags {
+				if f == "confusable" {
+					f = "confusable with " + strconv.Quote(cp.Confusable)
+				}
+				row[6] += " [" + f + "]"
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], uniseg.StringWidth(cell))
+		}
+	}
+
+	buf := &strings.Builder{}
+	for _, row := range rows {
+		for i, cell := range row {
+			buf.WriteString(cell)
+			if i < len(row)-1 {
+				buf.WriteString(strings.Repeat(" ", widths[i]-uniseg.StringWidth(cell)+2))
+			}
+		}
+		buf.WriteString("\n")
+	}
+	_, _ = fmt.Fprintf(buf, "%d bytes, %d codepoints, %d graphemes, width %d\n", d.Bytes, d.Codepoints, len(d.Graphemes), d.Width)
+	return buf.String()
+}
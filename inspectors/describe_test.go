@@ -0,0 +1,127 @@
+package inspectors
+
+import (
+	"bytes"
+	"context"
+	"github.com/urfave/cli/v3"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		graphemes  []string
+		codepoints []string
+		categories []string
+		flags      [][]string
+	}{
+		{
+			name:       "combining mark is one grapheme",
+			input:      "e\u0301",
+			graphemes:  []string{"e\u0301"},
+			codepoints: []string{"U+0065", "U+0301"},
+			categories: []string{"Ll", "Mn"},
+			flags:      [][]string{nil, nil},
+		},
+		{
+			name:       "invisible and bidi control",
+			input:      "a\u200b\u202e",
+			graphemes:  []string{"a", "\u200b", "\u202e"},
+			codepoints: []string{"U+0061", "U+200B", "U+202E"},
+			categories: []string{"Ll", "Cf", "Cf"},
+			flags:      [][]string{nil, {"invisible"}, {"invisible", "bidi-control"}},
+		},
+		{
+			name:       "confusables",
+			input:      "\u0440\uff41",
+			graphemes:  []string{"\u0440", "\uff41"},
+			codepoints: []string{"U+0440", "U+FF41"},
+			categories: []string{"Ll", "Ll"},
+			flags:      [][]string{{"confusable"}, {"confusable"}},
+		},
+		{
+			name:       "emoji with modifier",
+			input:      "👍🏽",
+			graphemes:  []string{"👍🏽"},
+			codepoints: []string{"U+1F44D", "U+1F3FD"},
+			categories: []string{"So", "Sk"},
+			flags:      [][]string{nil, nil},
+		},
+		{
+			name:       "invalid utf-8",
+			input:      "\xff",
+			graphemes:  []string{"\xff"},
+			codepoints: []string{"invalid"},
+			categories: []string{""},
+			flags:      [][]string{{"invalid-utf8"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := describeString(tt.input)
+			var graphemes, codepoints, categories []string
+			var flags [][]string
+			for _, g := range d.Graphemes {
+				graphemes = append(graphemes, g.Text)
+				for _, cp := range g.Codepoints {
+					codepoints = append(codepoints, cp.Codepoint)
+					categories = append(categories, cp.Category)
+					flags = append(flags, cp.Flags)
+				}
+			}
+			if !reflect.DeepEqual(graphemes, tt.graphemes) {
+				t.Errorf("describeString() graphemes = %q, want %q", graphemes, tt.graphemes)
+			}
+			if !reflect.DeepEqual(codepoints, tt.codepoints) {
+				t.Errorf("describeString() codepoints = %v, want %v", codepoints, tt.codepoints)
+			}
+			if !reflect.DeepEqual(categories, tt.categories) {
+				t.Errorf("describeString() categories = %v, want %v", categories, tt.categories)
+			}
+			if !reflect.DeepEqual(flags, tt.flags) {
+				t.Errorf("describeString() flags = %v, want %v", flags, tt.flags)
+			}
+		})
+	}
+}
+
+func TestDescribeFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		input    string
+		contains string
+	}{
+		{name: "text from args", args: []string{"é"}, contains: "LATIN SMALL LETTER E WITH ACUTE"},
+		{name: "text from std in", input: "\u200b", contains: "ZERO WIDTH SPACE [invisible]"},
+		{name: "json", args: []string{"--format=json", "a"}, contains: `"codepoint":"U+0061"`},
+		{name: "yaml", args: []string{"--format=yaml", "a"}, contains: "name: LATIN SMALL LETTER A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			cmd := &cli.Command{
+				Reader: strings.NewReader(tt.input),
+				Writer: out,
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "format", Value: "text"},
+				},
+				Action: describe,
+			}
+
+			err := cmd.Run(context.Background(), append([]string{""}, tt.args...))
+			if err != nil {
+				t.Errorf("describe() error = %v", err)
+				return
+			}
+			if !strings.Contains(out.String(), tt.contains) {
+				t.Errorf("describe() got = %v, want it to contain %v", out.String(), tt.contains)
+			}
+		})
+	}
+}
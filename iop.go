@@ -6,9 +6,12 @@ import (
 	"github.com/atotto/clipboard"
 	"github.com/crholm/iop/conversions"
 	"github.com/crholm/iop/decoders"
+	"github.com/crholm/iop/documents"
 	"github.com/crholm/iop/encoders"
 	"github.com/crholm/iop/formatters"
 	"github.com/crholm/iop/generators"
+	"github.com/crholm/iop/highlight"
+	"github.com/crholm/iop/inspectors"
 	"github.com/urfave/cli/v3"
 	"io"
 	"os"
@@ -86,6 +89,7 @@ func createApp() *cli.Command {
 		Name:      "iop",
 		Usage:     "a tool for converting and formatting things from std in to std out",
 		UsageText: "You can use -- as piping between commands, eg. echo 124 | iop conv string-to-int -- encode hex -- clip copy",
+		Flags:     highlight.Flags(),
 		Commands: []*cli.Command{
 
 			{
@@ -147,6 +151,9 @@ func createApp() *cli.Command {
 				Usage:    "convert something",
 				Commands: conversions.Commands,
 			},
+			documents.DiffCommand,
+			documents.PatchCommand,
+			inspectors.DescribeCommand,
 		},
 	}
 	return app
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// base45Alphabet is the RFC 9285 alphabet, note that it includes space
+const base45Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// Base45Encode encodes pairs of bytes as 3 characters, least significant first, and a last odd byte as 2
+func Base45Encode(src []byte) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(src); i += 2 {
+		if i+1 == len(src) {
+			n := int(src[i])
+			sb.WriteByte(base45Alphabet[n%45])
+			sb.WriteByte(base45Alphabet[n/45])
+			break
+		}
+		n := int(src[i])<<8 | int(src[i+1])
+		sb.WriteByte(base45Alphabet[n%45])
+		sb.WriteByte(base45Alphabet[n/45%45])
+		sb.WriteByte(base45Alphabet[n/45/45])
+	}
+	return sb.String()
+}
+
+func Base45Decode(s string) ([]byte, error) {
+	if len(s)%3 == 1 {
+		return nil, fmt.Errorf("invalid base45 length %d, the last group has a single character", len(s))
+	}
+	res := make([]byte, 0, len(s)/3*2+1)
+	for i := 0; i < len(s); i += 3 {
+		end := min(i+3, len(s))
+		n, factor := 0, 1
+		for j := i; j < end; j++ {
+			v := strings.IndexByte(base45Alphabet, s[j])
+			if v < 0 {
+				return nil, fmt.Errorf("invalid base45 character %q at position %d", s[j], j)
+			}
+			n += v * factor
+			factor *= 45
+		}
+		if end-i == 2 {
+			if n > 0xff {
+				return nil, fmt.Errorf("base45 group %q at position %d is out of range", s[i:end], i)
+			}
+			res = append(res, byte(n))
+			break
+		}
+		if n > 0xffff {
+			return nil, fmt.Errorf("base45 group %q at position %d is out of range", s[i:end], i)
+		}
+		res = append(res, byte(n>>8), byte(n))
+	}
+	return res, nil
+}
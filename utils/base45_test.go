@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBase45(t *testing.T) {
+	// the examples of RFC 9285
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: ""},
+		{input: "AB", expected: "BB8"},
+		{input: "Hello!!", expected: "%69 VD92EX0"},
+		{input: "base-45", expected: "UJCLQE7W581"},
+		{input: "ietf!", expected: "QED8WEX0"},
+		{input: "\x00\x00\x00", expected: "00000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := Base45Encode([]byte(tt.input))
+			if got != tt.expected {
+				t.Errorf("Base45Encode() got = %v, want %v", got, tt.expected)
+			}
+			back, err := Base45Decode(got)
+			if err != nil {
+				t.Fatalf("Base45Decode() error = %v", err)
+			}
+			if !bytes.Equal(back, []byte(tt.input)) {
+				t.Errorf("Base45Decode() got = %q, want %q", back, tt.input)
+			}
+		})
+	}
+}
+
+func TestBase45Errors(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{input: "GGW", wantErr: `base45 group "GGW" at position 0 is out of range`},
+		{input: "BB8:;", wantErr: `invalid base45 character ';' at position 4`},
+		{input: "BB8B", wantErr: "the last group has a single character"},
+		{input: "BB8::", wantErr: "out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Base45Decode(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Base45Decode() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// Base85 is a base 85 alphabet that encodes groups of 4 bytes as 5 characters, big endian. A partial last group is
+// padded and its extra characters dropped, unless the encoding is Strict, like Z85, which only takes whole groups
+type Base85 struct {
+	Name     string
+	Strict   bool
+	alphabet string
+	index    [256]int
+}
+
+func NewBase85(name string, alphabet string, strict bool) *Base85 {
+	b := &Base85{Name: name, alphabet: alphabet, Strict: strict}
+	for i := range b.index {
+		b.index[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		b.index[alphabet[i]] = i
+	}
+	return b
+}
+
+var (
+	// Z85 is the ZeroMQ encoding, https://rfc.zeromq.org/spec/32/
+	Z85 = NewBase85("z85", "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#", true)
+	// B85 is the RFC 1924 alphabet that git binary patches and python's base64.b85encode use
+	B85 = NewBase85("b85", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~", false)
+)
+
+type base85Encoder struct {
+	enc   *Base85
+	w     io.Writer
+	buf   [4]byte
+	n     int
+	total int
+}
+
+func NewBase85Encoder(enc *Base85, w io.Writer) io.WriteCloser {
+	return &base85Encoder{enc: enc, w: w}
+}
+
+func (e *base85Encoder) group(n int) error {
+	v := uint32(e.buf[0])<<24 | uint32(e.buf[1])<<16 | uint32(e.buf[2])<<8 | uint32(e.buf[3])
+	var out [5]byte
+	for i := 4; i >= 0; i-- {
+		out[i] = e.enc.alphabet[v%85]
+		v /= 85
+	}
+	_, err := e.w.Write(out[:n+1])
+	return err
+}
+
+func (e *base85Encoder) Write(p []byte) (int, error) {
+	for i, c := range p {
+		e.buf[e.n] = c
+		e.n++
+		e.total++
+		if e.n == 4 {
+			if err := e.group(4); err != nil {
+				return i, err
+			}
+			e.n = 0
+		}
+	}
+	return len(p), nil
+}
+
+// Close writes the last partial group
+func (e *base85Encoder) Close() error {
+	if e.n == 0 {
+		return nil
+	}
+	if e.enc.Strict {
+		return fmt.Errorf("%s input length has to be a multiple of 4, got %d", e.enc.Name, e.total)
+	}
+	for i := e.n; i < 4; i++ {
+		e.buf[i] = 0
+	}
+	n := e.n
+	e.n = 0
+	return e.group(n)
+}
+
+type base85Decoder struct {
+	enc   *Base85
+	r     *bufio.Reader
+	group [5]int
+	n     int
+	pos   int
+	out   []byte
+	err   error
+}
+
+// NewBase85Decoder decodes from r, skipping white space
+func NewBase85Decoder(enc *Base85, r io.Reader) io.Reader {
+	return &base85Decoder{enc: enc, r: bufio.NewReader(r)}
+}
+
+func (d *base85Decoder) flush(n int) error {
+	var v uint64
+	for _, c := range d.group {
+		v = v*85 + uint64(c)
+	}
+	if v > 0xffffffff {
+		return fmt.Errorf("%s group ending at position %d is out of range", d.enc.Name, d.pos-1)
+	}
+	b := []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	d.out = append(d.out, b[:n]...)
+	return nil
+}
+
+func (d *base85Decoder) Read(p []byte) (int, error) {
+	for len(d.out) == 0 && d.err == nil {
+		c, err := d.r.ReadByte()
+		if err == io.EOF {
+			d.err = io.EOF
+			switch {
+			case d.n == 0:
+			case d.enc.Strict:
+				d.err = fmt.Errorf("%s string length has to be a multiple of 5, it ends with %d extra characters", d.enc.Name, d.n)
+			case d.n == 1:
+				d.err = fmt.Errorf("%s string ends with a single character group", d.enc.Name)
+			default:
+				// a partial group is padded with the highest digit
+				for i := d.n; i < 5; i++ {
+					d.group[i] = 84
+				}
+				if err := d.flush(d.n - 1); err != nil {
+					d.err = err
+				}
+			}
+			break
+		}
+		if err != nil {
+			d.err = err
+			break
+		}
+		d.pos++
+		if c == ' ' || c == '\n' || c == '\r' || c == '\t' {
+			continue
+		}
+		v := d.enc.index[c]
+		if v < 0 {
+			d.err = fmt.Errorf("invalid %s character %q at position %d", d.enc.Name, c, d.pos-1)
+			break
+		}
+		d.group[d.n] = v
+		d.n++
+		if d.n == 5 {
+			d.n = 0
+			if err := d.flush(4); err != nil {
+				d.err = err
+			}
+		}
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	if n > 0 {
+		return n, nil
+	}
+	return 0, d.err
+}
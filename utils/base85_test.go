@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestBase85(t *testing.T) {
+	tests := []struct {
+		name     string
+		enc      *Base85
+		input    []byte
+		expected string
+	}{
+		{name: "z85 spec vector", enc: Z85, input: []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}, expected: "HelloWorld"},
+		{name: "z85 empty", enc: Z85, input: []byte{}, expected: ""},
+		{name: "b85", enc: B85, input: []byte("hello"), expected: "Xk~0{Zv"},
+		{name: "b85 zeros", enc: B85, input: []byte{0, 0, 0, 0, 0}, expected: "0000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			w := NewBase85Encoder(tt.enc, out)
+			if _, err := w.Write(tt.input); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("encode got = %v, want %v", out.String(), tt.expected)
+			}
+
+			back, err := io.ReadAll(NewBase85Decoder(tt.enc, strings.NewReader(out.String())))
+			if err != nil {
+				t.Fatalf("decode error = %v", err)
+			}
+			if !bytes.Equal(back, tt.input) {
+				t.Errorf("decode got = %x, want %x", back, tt.input)
+			}
+		})
+	}
+}
+
+func TestBase85Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		enc     *Base85
+		input   string
+		wantErr string
+	}{
+		{name: "z85 length", enc: Z85, input: "Hello", wantErr: ""},
+		{name: "z85 partial group", enc: Z85, input: "HelloWor", wantErr: "z85 string length has to be a multiple of 5"},
+		{name: "invalid character", enc: B85, input: "Xk~0\"Zv", wantErr: `invalid b85 character '"' at position 4`},
+		{name: "single character group", enc: B85, input: "Xk~0{Z", wantErr: "single character group"},
+		{name: "out of range", enc: B85, input: "~~~~~", wantErr: "out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := io.ReadAll(NewBase85Decoder(tt.enc, strings.NewReader(tt.input)))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("decode error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("decode error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	w := NewBase85Encoder(Z85, &bytes.Buffer{})
+	_, _ = w.Write([]byte("abc"))
+	if err := w.Close(); err == nil || err.Error() != "z85 input length has to be a multiple of 4, got 3" {
+		t.Errorf("Close() error = %v", err)
+	}
+}
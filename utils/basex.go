@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// BaseX encodes bytes as a big number in an arbitrary alphabet, like base58, base62 and base36. Leading zero bytes
+// are written as leading zero digits, so that they round trip
+type BaseX struct {
+	Name     string
+	alphabet string
+	index    [256]int
+}
+
+func NewBaseX(name string, alphabet string) *BaseX {
+	b := &BaseX{Name: name, alphabet: alphabet}
+	for i := range b.index {
+		b.index[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		b.index[alphabet[i]] = i
+	}
+	return b
+}
+
+var (
+	Base58Bitcoin = NewBaseX("base58", "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	Base58Flickr  = NewBaseX("base58", "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ")
+	Base58Ripple  = NewBaseX("base58", "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz")
+	Base62        = NewBaseX("base62", "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	Base36        = NewBaseX("base36", "0123456789abcdefghijklmnopqrstuvwxyz")
+)
+
+// Base58Alphabets are the base58 alphabets by name
+var Base58Alphabets = map[string]*BaseX{
+	"bitcoin": Base58Bitcoin,
+	"flickr":  Base58Flickr,
+	"ripple":  Base58Ripple,
+}
+
+func (b *BaseX) Encode(src []byte) string {
+	zeros := 0
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
+
+	n := new(big.Int).SetBytes(src)
+	base := big.NewInt(int64(len(b.alphabet)))
+	mod := new(big.Int)
+	var digits []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		digits = append(digits, b.alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		digits = append(digits, b.alphabet[0])
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+func (b *BaseX) Decode(s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == b.alphabet[0] {
+		zeros++
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(int64(len(b.alphabet)))
+	for i := 0; i < len(s); i++ {
+		v := b.index[s[i]]
+		if v < 0 {
+			r, _ := utf8.DecodeRuneInString(s[i:])
+			return nil, fmt.Errorf("invalid %s character %q at position %d", b.Name, r, i)
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(v)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// DecodeFold decodes case insensitive alphabets, like base36, in either case
+func (b *BaseX) DecodeFold(s string) ([]byte, error) {
+	return b.Decode(strings.ToLower(s))
+}
+
+func base58Checksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
+
+// Base58CheckEncode encodes a version byte and payload followed by the first 4 bytes of its double sha256
+func Base58CheckEncode(b *BaseX, version byte, payload []byte) string {
+	data := append([]byte{version}, payload...)
+	return b.Encode(append(data, base58Checksum(data)...))
+}
+
+// Base58CheckDecode decodes and validates base58check, returning the version byte and payload
+func Base58CheckDecode(b *BaseX, s string) (byte, []byte, error) {
+	data, err := b.Decode(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(data) < 5 {
+		return 0, nil, errors.New("base58check data is too short, it needs a version byte and a 4 byte checksum")
+	}
+	payload, checksum := data[:len(data)-4], data[len(data)-4:]
+	if want := base58Checksum(payload); string(want) != string(checksum) {
+		return 0, nil, fmt.Errorf("base58check checksum mismatch, got %x, want %x", checksum, want)
+	}
+	return payload[0], payload[1:], nil
+}
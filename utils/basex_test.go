@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBaseX(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     *BaseX
+		input    []byte
+		expected string
+	}{
+		{name: "base58 empty", base: Base58Bitcoin, input: []byte{}, expected: ""},
+		{name: "base58 text", base: Base58Bitcoin, input: []byte("Hello World!"), expected: "2NEpo7TZRRrLZSi2U"},
+		{name: "base58 leading zeros", base: Base58Bitcoin, input: []byte{0, 0, 0x28, 0x7f, 0xb4, 0xcd}, expected: "11233QC4"},
+		{name: "base58 only zeros", base: Base58Bitcoin, input: []byte{0, 0, 0}, expected: "111"},
+		{name: "base62", base: Base62, input: []byte{0x01, 0x00}, expected: "48"},
+		{name: "base62 leading zeros", base: Base62, input: []byte{0, 0x3d}, expected: "0z"},
+		{name: "base36", base: Base36, input: []byte{0x01, 0x00}, expected: "74"},
+		{name: "base36 leading zeros", base: Base36, input: []byte{0, 0, 0x23}, expected: "00z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.base.Encode(tt.input)
+			if got != tt.expected {
+				t.Errorf("Encode() got = %v, want %v", got, tt.expected)
+			}
+			back, err := tt.base.Decode(got)
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !bytes.Equal(back, tt.input) {
+				t.Errorf("Decode() got = %x, want %x", back, tt.input)
+			}
+		})
+	}
+}
+
+func TestBaseXRoundTrip(t *testing.T) {
+	inputs := [][]byte{
+		{0},
+		{0, 0, 1},
+		{0xff, 0xff, 0xff, 0xff},
+		[]byte("the quick brown fox jumps over the lazy dog"),
+		bytes.Repeat([]byte{0, 0xab}, 100),
+	}
+	for _, b := range []*BaseX{Base58Bitcoin, Base58Flickr, Base58Ripple, Base62, Base36} {
+		for _, in := range inputs {
+			back, err := b.Decode(b.Encode(in))
+			if err != nil || !bytes.Equal(back, in) {
+				t.Errorf("%s round trip of %x got = %x, %v", b.Name, in, back, err)
+			}
+		}
+	}
+}
+
+func TestBaseXDecodeError(t *testing.T) {
+	_, err := Base58Bitcoin.Decode("abc0def")
+	if err == nil || err.Error() != `invalid base58 character '0' at position 3` {
+		t.Errorf("Decode() error = %v", err)
+	}
+}
+
+func TestBaseXDecodeFold(t *testing.T) {
+	got, err := Base36.DecodeFold("00Z")
+	if err != nil || !bytes.Equal(got, []byte{0, 0, 0x23}) {
+		t.Errorf("DecodeFold() got = %x, %v", got, err)
+	}
+}
+
+func TestBase58Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    *BaseX
+		version byte
+		payload []byte
+		encoded string
+	}{
+		{name: "bitcoin burn address", base: Base58Bitcoin, version: 0, payload: make([]byte, 20), encoded: "1111111111111111111114oLvT2"},
+		{name: "ripple account zero", base: Base58Ripple, version: 0, payload: make([]byte, 20), encoded: "rrrrrrrrrrrrrrrrrrrrrhoLvTp"},
+		{name: "version byte", base: Base58Bitcoin, version: 5, payload: []byte("x")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Base58CheckEncode(tt.base, tt.version, tt.payload)
+			if tt.encoded != "" && got != tt.encoded {
+				t.Errorf("Base58CheckEncode() got = %v, want %v", got, tt.encoded)
+			}
+			version, payload, err := Base58CheckDecode(tt.base, got)
+			if err != nil {
+				t.Fatalf("Base58CheckDecode() error = %v", err)
+			}
+			if version != tt.version || !bytes.Equal(payload, tt.payload) {
+				t.Errorf("Base58CheckDecode() got = %d %x, want %d %x", version, payload, tt.version, tt.payload)
+			}
+		})
+	}
+
+	_, _, err := Base58CheckDecode(Base58Bitcoin, "1111111111111111111114oLvT3")
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Base58CheckDecode() error = %v, want checksum mismatch", err)
+	}
+}
@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Bech32 variants, BIP-173 and BIP-350, by their checksum constant
+const (
+	Bech32  = "bech32"
+	Bech32m = "bech32m"
+)
+
+var bech32Constants = map[string]uint32{
+	Bech32:  1,
+	Bech32m: 0x2bc830a3,
+}
+
+// SegwitVariant returns the variant of segwit addresses with the witness version, BIP-350 keeps bech32 for version 0
+// and uses bech32m for 1-16
+func SegwitVariant(version byte) string {
+	if version == 0 {
+		return Bech32
+	}
+	return Bech32m
+}
+
+func bech32Polymod(values []byte) uint32 {
+	gen := []uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	res := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]>>5)
+	}
+	res = append(res, 0)
+	for i := 0; i < len(hrp); i++ {
+		res = append(res, hrp[i]&31)
+	}
+	return res
+}
+
+// bech32Variant returns the variant whose checksum matches, or ""
+func bech32Variant(hrp string, data []byte) string {
+	pm := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	for variant, c := range bech32Constants {
+		if pm == c {
+			return variant
+		}
+	}
+	return ""
+}
+
+// ConvertBits regroups bits, eg. 8 bit bytes into 5 bit groups. When pad is false, left over bits have to be zero
+// padding of less than from bits
+func ConvertBits(data []byte, from uint, to uint, pad bool) ([]byte, error) {
+	acc, bits := uint32(0), uint(0)
+	maxv := uint32(1)<<to - 1
+	var res []byte
+	for _, v := range data {
+		if uint32(v)>>from != 0 {
+			return nil, fmt.Errorf("value %d does not fit in %d bits", v, from)
+		}
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			res = append(res, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			res = append(res, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding when converting from 5 bit groups")
+	}
+	return res, nil
+}
+
+// Bech32Encode encodes 5 bit values with a human readable part and the checksum of the variant
+func Bech32Encode(hrp string, data []byte, variant string) (string, error) {
+	constant, ok := bech32Constants[variant]
+	if !ok {
+		return "", fmt.Errorf("unknown variant %s, expected bech32 or bech32m", variant)
+	}
+	if len(hrp) == 0 {
+		return "", errors.New("the human readable part can not be empty")
+	}
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", fmt.Errorf("invalid character %q in the human readable part at position %d", hrp[i], i)
+		}
+	}
+	if strings.ToLower(hrp) != hrp && strings.ToUpper(hrp) != hrp {
+		return "", errors.New("the human readable part can not be mixed case")
+	}
+	lower := strings.ToLower(hrp)
+
+	values := append(bech32HRPExpand(lower), data...)
+	pm := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ constant
+
+	sb := strings.Builder{}
+	sb.WriteString(lower)
+	sb.WriteByte('1')
+	for _, v := range data {
+		sb.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(pm>>(5*(5-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+// Bech32Decode decodes a bech32 or bech32m string into its human readable part, 5 bit values without the checksum
+// and the variant. A bad checksum is reported with the position of the wrong character when there is a single one
+func Bech32Decode(s string) (hrp string, data []byte, variant string, err error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, "", errors.New("bech32 strings can not be mixed case")
+	}
+	s = strings.ToLower(s)
+	for i := 0; i < len(s); i++ {
+		if s[i] < 33 || s[i] > 126 {
+			return "", nil, "", fmt.Errorf("invalid character %q at position %d", s[i], i)
+		}
+	}
+
+	sep := strings.LastIndexByte(s, '1')
+	switch {
+	case sep < 0:
+		return "", nil, "", errors.New("missing the separator 1 between the human readable part and the data")
+	case sep == 0:
+		return "", nil, "", errors.New("the human readable part is empty")
+	case len(s)-sep-1 < 6:
+		return "", nil, "", errors.New("the data part is shorter than the 6 character checksum")
+	}
+	hrp = s[:sep]
+
+	data = make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		v := strings.IndexByte(bech32Charset, s[i])
+		if v < 0 {
+			return "", nil, "", fmt.Errorf("invalid data character %q at position %d", s[i], i)
+		}
+		data = append(data, byte(v))
+	}
+
+	variant = bech32Variant(hrp, data)
+	if variant == "" {
+		return "", nil, "", bech32ChecksumError(hrp, data, sep+1)
+	}
+	return hrp, data[:len(data)-6], variant, nil
+}
+
+// bech32ChecksumError finds a single substituted character that would make the checksum valid. The code detects,
+// but can not locate, more errors than that
+func bech32ChecksumError(hrp string, data []byte, offset int) error {
+	if len(data) <= 1024 {
+		fixed := make([]byte, len(data))
+		for i := range data {
+			copy(fixed, data)
+			for v := byte(0); v < 32; v++ {
+				if v == data[i] {
+					continue
+				}
+				fixed[i] = v
+				if bech32Variant(hrp, fixed) != "" {
+					return fmt.Errorf("invalid checksum, the character at position %d is wrong, it might be %q", offset+i, bech32Charset[v])
+				}
+			}
+		}
+	}
+	return errors.New("invalid checksum, the error can not be located to a single data character")
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestBech32Decode(t *testing.T) {
+	// the test vectors of BIP-173 and BIP-350
+	tests := []struct {
+		input   string
+		hrp     string
+		variant string
+	}{
+		{input: "A12UEL5L", hrp: "a", variant: Bech32},
+		{input: "a12uel5l", hrp: "a", variant: Bech32},
+		{input: "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", hrp: "abcdef", variant: Bech32},
+		{input: "split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", hrp: "split", variant: Bech32},
+		{input: "?1ezyfcl", hrp: "?", variant: Bech32},
+		{input: "a1lqfn3a", hrp: "a", variant: Bech32m},
+		{input: "abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", hrp: "abcdef", variant: Bech32m},
+		{input: "?1v759aa", hrp: "?", variant: Bech32m},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			hrp, data, variant, err := Bech32Decode(tt.input)
+			if err != nil {
+				t.Fatalf("Bech32Decode() error = %v", err)
+			}
+			if hrp != tt.hrp || variant != tt.variant {
+				t.Errorf("Bech32Decode() got = %v %v, want %v %v", hrp, variant, tt.hrp, tt.variant)
+			}
+			got, err := Bech32Encode(hrp, data, variant)
+			if err != nil {
+				t.Fatalf("Bech32Encode() error = %v", err)
+			}
+			if got != strings.ToLower(tt.input) {
+				t.Errorf("Bech32Encode() got = %v, want %v", got, strings.ToLower(tt.input))
+			}
+		})
+	}
+}
+
+func TestBech32Segwit(t *testing.T) {
+	tests := []struct {
+		input   string
+		version byte
+		program string
+	}{
+		{input: "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", version: 0, program: "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{input: "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y", version: 1, program: "751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{input: "BC1SW50QGDZ25J", version: 16, program: "751e"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, data, _, err := Bech32Decode(tt.input)
+			if err != nil {
+				t.Fatalf("Bech32Decode() error = %v", err)
+			}
+			program, err := ConvertBits(data[1:], 5, 8, false)
+			if err != nil {
+				t.Fatalf("ConvertBits() error = %v", err)
+			}
+			if data[0] != tt.version || hex.EncodeToString(program) != tt.program {
+				t.Errorf("Bech32Decode() got = %d %x, want %d %v", data[0], program, tt.version, tt.program)
+			}
+		})
+	}
+}
+
+func TestBech32Errors(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{input: "a12UEL5L", wantErr: "mixed case"},
+		{input: "pzry9x0s0muk", wantErr: "missing the separator"},
+		{input: "1pzry9x0s0muk", wantErr: "human readable part is empty"},
+		{input: "x1b4n0q5v", wantErr: `invalid data character 'b' at position 2`},
+		{input: "li1dgmt3", wantErr: "shorter than the 6 character checksum"},
+		{input: "a12uel5m", wantErr: "the character at position 7 is wrong, it might be 'l'"},
+		{input: "abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxq", wantErr: "position 44 is wrong, it might be 'w'"},
+		{input: "a1qqel5l", wantErr: "can not be located"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, _, _, err := Bech32Decode(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Bech32Decode() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvertBitsPadding(t *testing.T) {
+	if _, err := ConvertBits([]byte{31}, 5, 8, false); err == nil {
+		t.Errorf("ConvertBits() expected an error for non zero padding")
+	}
+	got, err := ConvertBits([]byte{0xff}, 8, 5, true)
+	if err != nil || hex.EncodeToString(got) != "1f1c" {
+		t.Errorf("ConvertBits() got = %x, %v, want 1f1c", got, err)
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Marshaller returns the marshal function for an output format, json, xml, yaml, toml or text. Unknown formats are json
+func Marshaller(format string) func(any) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.Marshal
+	case "xml":
+		return xml.Marshal
+	case "yaml", "yml":
+		return yaml.Marshal
+	case "toml":
+		return toml.Marshal
+	case "text", "txt":
+		return func(z any) ([]byte, error) {
+			return []byte(fmt.Sprintf("%v", z)), nil
+		}
+	default:
+		return json.Marshal
+	}
+}